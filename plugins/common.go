@@ -167,6 +167,25 @@ func createGolangSdkProviderClient(params CloudProviderParam) (*golangsdk.Provid
 	return client, nil
 }
 
+// the golangsdk networking v2 client, used by lb whitelists and vpc firewalls
+func createNetworkV2ServiceClient(params CloudProviderParam) (*golangsdk.ServiceClient, error) {
+	client, err := createGolangSdkProviderClient(params)
+	if err != nil {
+		logrus.Errorf("get golangsdk provider client failed, error=%v", err)
+		return nil, err
+	}
+
+	cloudMap, _ := GetMapFromString(params.CloudParams)
+	sc, err := goOpenstack.NewNetworkV2(client, golangsdk.EndpointOpts{
+		Region: cloudMap[CLOUD_PARAM_REGION],
+	})
+	if err != nil {
+		logrus.Errorf("createNetworkV2ServiceClient meet err=%v", err)
+		return nil, err
+	}
+	return sc, err
+}
+
 func GetMapFromString(providerParams string) (map[string]string, error) {
 	rtnMap := make(map[string]string)
 	params := strings.Split(providerParams, ";")
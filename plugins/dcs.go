@@ -20,8 +20,18 @@ const (
 	INSTANCE_TYPE_HA      = "ha"
 	INSTANCE_TYPE_CLUSTER = "cluster"
 	INSTANCE_TYPE_PROXY   = "proxy"
+
+	DCS_ENGINE_REDIS     = "redis"
+	DCS_ENGINE_MEMCACHED = "memcached"
+
+	DCS_STATUS_RUNNING = "RUNNING"
 )
 
+var dcsEngineNameMap = map[string]string{
+	DCS_ENGINE_REDIS:     "Redis",
+	DCS_ENGINE_MEMCACHED: "Memcached",
+}
+
 func createDcsServiceClient(params CloudProviderParam) (*golangsdk.ServiceClient, error) {
 	client, err := createGolangSdkProviderClient(params)
 	if err != nil {
@@ -43,6 +53,14 @@ var dcsActions = make(map[string]Action)
 func init() {
 	dcsActions["create"] = new(DcsCreateAction)
 	dcsActions["delete"] = new(DcsDeleteAction)
+	dcsActions["resize"] = new(DcsResizeAction)
+	dcsActions["backup"] = new(DcsBackupAction)
+	dcsActions["restore"] = new(DcsRestoreAction)
+	dcsActions["change-password"] = new(DcsChangePasswordAction)
+	dcsActions["reset-password"] = new(DcsResetPasswordAction)
+	dcsActions["add-whitelist"] = new(DcsAddWhitelistAction)
+	dcsActions["remove-whitelist"] = new(DcsRemoveWhitelistAction)
+	dcsActions["update-config"] = new(DcsUpdateConfigAction)
 }
 
 type DcsPlugin struct {
@@ -68,10 +86,12 @@ type DcsCreateInput struct {
 	Seed          string `json:"seed,omitempty"`
 	Id            string `json:"id,omitempty"`
 	Name          string `json:"name,omitempty"`
+	Engine        string `json:"engine,omitempty"` //redis or memcached, default redis
 	InstanceType  string `json:"instance_type,omitempty"`
 	EngineVersion string `json:"engine_version,omitempty"`
 	Capacity      string `json:"capacity,omitempty"`
 	Password      string `json:"password,omitempty"`
+	AccessUser    string `json:"access_user,omitempty"` //only for memcached
 
 	VpcId           string `json:"vpc_id,omitempty"`
 	SubnetId        string `json:"subnet_id,omitempty"`
@@ -96,11 +116,12 @@ type DcsCreateOutputs struct {
 type DcsCreateOutput struct {
 	CallBackParameter
 	Result
-	Guid      string `json:"guid,omitempty"`
-	Id        string `json:"id,omitempty"`
-	PrivateIp string `json:"private_ip,omitempty"`
-	Password  string `json:"password,omitempty"`
-	Port      string `json:"port"`
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	PrivateIp  string `json:"private_ip,omitempty"`
+	Password   string `json:"password,omitempty"`
+	Port       string `json:"port"`
+	ShardCount string `json:"shard_count,omitempty"`
 }
 
 type DcsCreateAction struct {
@@ -124,19 +145,25 @@ func checkCreateDcsParams(input DcsCreateInput) error {
 		return fmt.Errorf("empty name")
 	}
 
-	validInstanceTypes := []string{
-		INSTANCE_TYPE_SINGLE, INSTANCE_TYPE_HA,
-		INSTANCE_TYPE_CLUSTER, INSTANCE_TYPE_PROXY,
+	engine := getDcsEngine(input.Engine)
+	if err := isValidStringValue("engine", engine, []string{DCS_ENGINE_REDIS, DCS_ENGINE_MEMCACHED}); err != nil {
+		return err
 	}
-	if err := isValidStringValue("instanceType", input.InstanceType, validInstanceTypes); err != nil {
+
+	if err := checkDcsInstanceType(engine, input.InstanceType); err != nil {
 		return err
 	}
 
-	validRedisVersions := []string{
-		"3.0", "4.0", "5.0",
+	if engine == DCS_ENGINE_REDIS {
+		validRedisVersions := []string{
+			"3.0", "4.0", "5.0",
+		}
+		if err := isValidStringValue("redisVersion", input.EngineVersion, validRedisVersions); err != nil {
+			return err
+		}
 	}
-	if err := isValidStringValue("redisVersion", input.EngineVersion, validRedisVersions); err != nil {
-		return err
+	if engine == DCS_ENGINE_MEMCACHED && input.AccessUser == "" {
+		return fmt.Errorf("empty accessUser")
 	}
 
 	if input.Capacity == "" {
@@ -175,6 +202,24 @@ func checkCreateDcsParams(input DcsCreateInput) error {
 	return nil
 }
 
+// engine is redis by default
+func getDcsEngine(engine string) string {
+	if engine == "" {
+		return DCS_ENGINE_REDIS
+	}
+	return strings.ToLower(engine)
+}
+
+func checkDcsInstanceType(engine string, instanceType string) error {
+	validInstanceTypes := []string{
+		INSTANCE_TYPE_SINGLE, INSTANCE_TYPE_HA,
+	}
+	if engine == DCS_ENGINE_REDIS {
+		validInstanceTypes = append(validInstanceTypes, INSTANCE_TYPE_CLUSTER, INSTANCE_TYPE_PROXY)
+	}
+	return isValidStringValue("instanceType", instanceType, validInstanceTypes)
+}
+
 func getDcsInfoById(cloudProviderParam CloudProviderParam, id string) (*instances.Instance, error) {
 	sc, err := createDcsServiceClient(cloudProviderParam)
 	if err != nil {
@@ -202,7 +247,7 @@ func waitDcsCreateOk(sc *golangsdk.ServiceClient, id string) (*instances.Instanc
 			finalErr = fmt.Errorf("create dcs status=%v", dcsInfo.Status)
 			break
 		}
-		if dcsInfo.Status == "RUNNING" {
+		if dcsInfo.Status == DCS_STATUS_RUNNING {
 			return dcsInfo, nil
 		}
 	}
@@ -270,16 +315,54 @@ func buildBssParam(input DcsCreateInput) *instances.BssParam {
 	return &bssParam
 }
 
-func isAzsAllInAzMaps(azs []string, azMap map[string]string) error {
-	for _, az := range azs {
-		if _, ok := azMap[az]; !ok {
-			return fmt.Errorf("az %v can't be found in map(%++v)", az, azMap)
+// find the product by engine, cache mode, version, capacity and charging type
+func getDcsProduct(sc *golangsdk.ServiceClient, engine, instanceType, engineVersion, capacity, payMode, cpuType string) (*products.Product, error) {
+	response, err := products.Get(sc).Extract()
+	if err != nil {
+		return nil, err
+	}
+	for i, product := range response.Products {
+		if product.Engine != engine || product.CpuType != cpuType || product.CacheMode != instanceType {
+			continue
+		}
+
+		//检查付费方式是否匹配
+		if false == strings.EqualFold(payMode, product.ChargingType) {
+			continue
+		}
+
+		//检查版本是否匹配："engine_versions": "4.0;5.0", memcached没有版本
+		if engine == DCS_ENGINE_REDIS {
+			versions := strings.Split(product.EngineVersions, ";")
+			if err := isValidStringValue("engineVersion", engineVersion, versions); err != nil {
+				continue
+			}
+		}
+
+		for _, flavor := range product.Flavors {
+			//检查容量是否匹配
+			if flavor.Capacity == capacity {
+				return &response.Products[i], nil
+			}
 		}
 	}
-	return nil
+	return nil, fmt.Errorf("can't find the desire %v productId", engine)
+}
+
+func getDcsProductById(sc *golangsdk.ServiceClient, productId string) (*products.Product, error) {
+	response, err := products.Get(sc).Extract()
+	if err != nil {
+		return nil, err
+	}
+	for i, product := range response.Products {
+		if product.ProductID == productId {
+			return &response.Products[i], nil
+		}
+	}
+	return nil, fmt.Errorf("can't find dcs product(%v)", productId)
 }
 
-func getRedisProductId(input DcsCreateInput, azs []string) (string, []string, error) {
+func getDcsProductId(input DcsCreateInput, azs []string) (string, []string, error) {
 	sc, err := createDcsServiceClient(input.CloudProviderParam)
 	if err != nil {
 		return "", azs, err
@@ -302,43 +385,31 @@ func getRedisProductId(input DcsCreateInput, azs []string) (string, []string, er
 		}
 	}
 
-	response, err := products.Get(sc).Extract()
+	product, err := getDcsProduct(sc, getDcsEngine(input.Engine), input.InstanceType, input.EngineVersion, input.Capacity, payMode, "x86_64")
 	if err != nil {
 		return "", azs, err
 	}
-	for _, product := range response.Products {
-		if product.Engine != "redis" || product.CpuType != "x86_64" || product.CacheMode != input.InstanceType {
-			continue
-		}
-
-		//检查付费方式是否匹配
-		if false == strings.EqualFold(payMode, product.ChargingType) {
-			continue
-		}
-
-		//检查版本是否匹配："engine_versions": "4.0;5.0",
-		versions := strings.Split(product.EngineVersions, ";")
-		if err := isValidStringValue("engineVersion", input.EngineVersion, versions); err != nil {
-			continue
-		}
-
-		for _, flavor := range product.Flavors {
-			//检查容量是否匹配
-			if flavor.Capacity != input.Capacity {
-				continue
-			}
-			if err := isAzsAllInAzMaps(azs, azMap); err == nil {
-				azCodes := []string{}
-				for _, az := range azs {
-					azCodes = append(azCodes, azMap[az])
-				}
-
-				return product.ProductID, azCodes, nil
-			}
-		}
+	azCodes := []string{}
+	for _, az := range azs {
+		azCodes = append(azCodes, azMap[az])
 	}
+	return product.ProductID, azCodes, nil
+}
 
-	return "", azs, fmt.Errorf("can't find the desire redis productId")
+// the shard count of cluster and proxy instances is decided by the capacity
+func getDcsShardCount(sc *golangsdk.ServiceClient, id string) (string, error) {
+	var resp struct {
+		ShardingCount int `json:"sharding_count"`
+	}
+	_, err := sc.Get(sc.ServiceURL("instances", id), &resp, nil)
+	if err != nil {
+		logrus.Errorf("get dcs[%v] sharding count meet err=%v", id, err)
+		return "", err
+	}
+	if resp.ShardingCount == 0 {
+		return "", nil
+	}
+	return strconv.Itoa(resp.ShardingCount), nil
 }
 
 func getAzSizeByInstanceType(instanceType string) (int, error) {
@@ -393,14 +464,14 @@ func createDcs(input DcsCreateInput) (output DcsCreateOutput, err error) {
 		azs = azs[0:azSize]
 	}
 
-	productId, azCodes, err := getRedisProductId(input, azs)
+	productId, azCodes, err := getDcsProductId(input, azs)
 	if err != nil {
 		return
 	}
 
 	opts := instances.CreateOps{
 		Name:             input.Name,
-		Engine:           "Redis",
+		Engine:           dcsEngineNameMap[getDcsEngine(input.Engine)],
 		EngineVersion:    input.EngineVersion,
 		Capacity:         capacity,
 		NoPasswordAccess: "false",
@@ -414,6 +485,9 @@ func createDcs(input DcsCreateInput) (output DcsCreateOutput, err error) {
 	if input.PrivateIp != "" && input.EngineVersion == "3.0" {
 		opts.PrivateIp = input.PrivateIp
 	}
+	if getDcsEngine(input.Engine) == DCS_ENGINE_MEMCACHED {
+		opts.AccessUser = input.AccessUser
+	}
 	if input.Port != "" && input.EngineVersion != "3.0" {
 		opts.Port, _ = strconv.Atoi(input.Port)
 	}
@@ -440,6 +514,12 @@ func createDcs(input DcsCreateInput) (output DcsCreateOutput, err error) {
 	}
 	output.PrivateIp = newDcsInstance.IP
 	output.Port = fmt.Sprintf("%v", newDcsInstance.Port)
+	if input.InstanceType == INSTANCE_TYPE_CLUSTER || input.InstanceType == INSTANCE_TYPE_PROXY {
+		output.ShardCount, err = getDcsShardCount(sc, output.Id)
+		if err != nil {
+			return
+		}
+	}
 
 	output.Password, err = utils.AesEnPassword(input.Guid, input.Seed, input.Password, utils.DEFALT_CIPHER)
 	if err != nil {
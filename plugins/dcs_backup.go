@@ -0,0 +1,315 @@
+package plugins
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DCS_LIST_PAGE_LIMIT = 50
+
+	DCS_BACKUP_STATUS_OK   = "succeed"
+	DCS_BACKUP_STATUS_BAD  = "failed"
+	DCS_RESTORE_STATUS_OK  = "succeed"
+	DCS_RESTORE_STATUS_BAD = "failed"
+)
+
+type DcsBackupRecord struct {
+	BackupId string `json:"backup_id"`
+	Status   string `json:"status"`
+}
+
+type DcsRestoreRecord struct {
+	RestoreId string `json:"restore_id"`
+	Status    string `json:"status"`
+}
+
+// return the status of the backup or restore record, empty means not found
+func getDcsRecordStatus(sc *golangsdk.ServiceClient, instanceId string, recordType string, recordId string) (string, error) {
+	for start := 1; ; start += DCS_LIST_PAGE_LIMIT {
+		var resp struct {
+			TotalNum int                `json:"total_num"`
+			Backups  []DcsBackupRecord  `json:"backup_record_response"`
+			Restores []DcsRestoreRecord `json:"restore_record_response"`
+		}
+		url := sc.ServiceURL("instances", instanceId, recordType) + fmt.Sprintf("?start=%d&limit=%d", start, DCS_LIST_PAGE_LIMIT)
+		if _, err := sc.Get(url, &resp, nil); err != nil {
+			logrus.Errorf("list dcs[%v] %v meet err=%v", instanceId, recordType, err)
+			return "", err
+		}
+
+		for _, backup := range resp.Backups {
+			if backup.BackupId == recordId {
+				return backup.Status, nil
+			}
+		}
+		for _, restore := range resp.Restores {
+			if restore.RestoreId == recordId {
+				return restore.Status, nil
+			}
+		}
+		if len(resp.Backups)+len(resp.Restores) == 0 || start+DCS_LIST_PAGE_LIMIT > resp.TotalNum {
+			break
+		}
+	}
+	return "", nil
+}
+
+func waitDcsRecordOk(sc *golangsdk.ServiceClient, instanceId string, recordType string, recordId string, okStatus string, badStatus string) error {
+	count := 1
+	for {
+		status, err := getDcsRecordStatus(sc, instanceId, recordType, recordId)
+		if err != nil {
+			return err
+		}
+		if status == okStatus {
+			return nil
+		}
+		if status == badStatus {
+			return fmt.Errorf("dcs[%v] %v(%v) status is %v", instanceId, recordType, recordId, status)
+		}
+
+		if count > 80 {
+			break
+		}
+		time.Sleep(15 * time.Second)
+		count++
+	}
+	return fmt.Errorf("after %vs, dcs[%v] %v(%v) is timeout", count*15, instanceId, recordType, recordId)
+}
+
+//--------------backup dcs------------------//
+type DcsBackupInputs struct {
+	Inputs []DcsBackupInput `json:"inputs,omitempty"`
+}
+
+type DcsBackupInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid         string `json:"guid,omitempty"`
+	Id           string `json:"id,omitempty"`
+	Remark       string `json:"remark,omitempty"`
+	BackupFormat string `json:"backup_format,omitempty"` //rdb or aof, default rdb
+}
+
+type DcsBackupOutputs struct {
+	Outputs []DcsBackupOutput `json:"outputs,omitempty"`
+}
+
+type DcsBackupOutput struct {
+	CallBackParameter
+	Result
+	Guid     string `json:"guid,omitempty"`
+	Id       string `json:"id,omitempty"`
+	BackupId string `json:"backup_id,omitempty"`
+}
+
+type DcsBackupAction struct {
+}
+
+func (action *DcsBackupAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DcsBackupInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func backupDcs(input DcsBackupInput) (output DcsBackupOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	backupFormat := "rdb"
+	if input.BackupFormat != "" {
+		if err = isValidStringValue("backupFormat", input.BackupFormat, []string{"rdb", "aof"}); err != nil {
+			return
+		}
+		backupFormat = input.BackupFormat
+	}
+
+	_, exist, err := isDcsExist(input.CloudProviderParam, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("dcs[%v] is not exist", input.Id)
+		return
+	}
+
+	sc, err := createDcsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	// the dcs v1 sdk has no backups, so the api is used directly
+	body := map[string]interface{}{
+		"remark":        input.Remark,
+		"backup_format": backupFormat,
+	}
+	var resp struct {
+		BackupId string `json:"backup_id"`
+	}
+	_, err = sc.Post(sc.ServiceURL("instances", input.Id, "backups"), body, &resp, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("backup dcs[%v] meet err=%v", input.Id, err)
+		return
+	}
+	output.BackupId = resp.BackupId
+
+	err = waitDcsRecordOk(sc, input.Id, "backups", resp.BackupId, DCS_BACKUP_STATUS_OK, DCS_BACKUP_STATUS_BAD)
+	return
+}
+
+func (action *DcsBackupAction) Do(inputs interface{}) (interface{}, error) {
+	dcs, _ := inputs.(DcsBackupInputs)
+	outputs := DcsBackupOutputs{}
+	var finalErr error
+
+	for _, input := range dcs.Inputs {
+		output, err := backupDcs(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dcs= %v are backuped", dcs)
+	return &outputs, finalErr
+}
+
+//--------------restore dcs------------------//
+type DcsRestoreInputs struct {
+	Inputs []DcsRestoreInput `json:"inputs,omitempty"`
+}
+
+type DcsRestoreInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid     string `json:"guid,omitempty"`
+	Id       string `json:"id,omitempty"`
+	BackupId string `json:"backup_id,omitempty"`
+	Remark   string `json:"remark,omitempty"`
+}
+
+type DcsRestoreOutputs struct {
+	Outputs []DcsRestoreOutput `json:"outputs,omitempty"`
+}
+
+type DcsRestoreOutput struct {
+	CallBackParameter
+	Result
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	RestoreId string `json:"restore_id,omitempty"`
+}
+
+type DcsRestoreAction struct {
+}
+
+func (action *DcsRestoreAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DcsRestoreInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func restoreDcs(input DcsRestoreInput) (output DcsRestoreOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	if input.BackupId == "" {
+		err = fmt.Errorf("backupId is empty")
+		return
+	}
+
+	sc, err := createDcsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	status, err := getDcsRecordStatus(sc, input.Id, "backups", input.BackupId)
+	if err != nil {
+		return
+	}
+	if status != DCS_BACKUP_STATUS_OK {
+		err = fmt.Errorf("dcs[%v] backup(%v) status(%v) is not %v", input.Id, input.BackupId, status, DCS_BACKUP_STATUS_OK)
+		return
+	}
+
+	// the dcs v1 sdk has no restores, so the api is used directly
+	body := map[string]interface{}{
+		"backup_id": input.BackupId,
+		"remark":    input.Remark,
+	}
+	var resp struct {
+		RestoreId string `json:"restore_id"`
+	}
+	_, err = sc.Post(sc.ServiceURL("instances", input.Id, "restores"), body, &resp, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("restore dcs[%v] from backup(%v) meet err=%v", input.Id, input.BackupId, err)
+		return
+	}
+	output.RestoreId = resp.RestoreId
+
+	err = waitDcsRecordOk(sc, input.Id, "restores", resp.RestoreId, DCS_RESTORE_STATUS_OK, DCS_RESTORE_STATUS_BAD)
+	return
+}
+
+func (action *DcsRestoreAction) Do(inputs interface{}) (interface{}, error) {
+	dcs, _ := inputs.(DcsRestoreInputs)
+	outputs := DcsRestoreOutputs{}
+	var finalErr error
+
+	for _, input := range dcs.Inputs {
+		output, err := restoreDcs(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dcs= %v are restored", dcs)
+	return &outputs, finalErr
+}
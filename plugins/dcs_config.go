@@ -0,0 +1,201 @@
+package plugins
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DCS_CONFIG_STATUS_OK  = "SUCCESS"
+	DCS_CONFIG_STATUS_BAD = "FAILURE"
+)
+
+type DcsConfigParam struct {
+	ParamId    string `json:"param_id"`
+	ParamName  string `json:"param_name"`
+	ParamValue string `json:"param_value"`
+	ValueRange string `json:"value_range,omitempty"`
+}
+
+type DcsConfigs struct {
+	ConfigStatus string           `json:"config_status"`
+	RedisConfig  []DcsConfigParam `json:"redis_config"`
+}
+
+func getDcsConfigs(sc *golangsdk.ServiceClient, id string) (*DcsConfigs, error) {
+	configs := DcsConfigs{}
+	_, err := sc.Get(sc.ServiceURL("instances", id, "configs"), &configs, nil)
+	if err != nil {
+		logrus.Errorf("get dcs[%v] configs meet err=%v", id, err)
+		return nil, err
+	}
+	return &configs, nil
+}
+
+func waitDcsConfigOk(sc *golangsdk.ServiceClient, id string) error {
+	count := 1
+	for {
+		configs, err := getDcsConfigs(sc, id)
+		if err != nil {
+			return err
+		}
+		if configs.ConfigStatus == DCS_CONFIG_STATUS_OK {
+			return nil
+		}
+		if configs.ConfigStatus == DCS_CONFIG_STATUS_BAD {
+			return fmt.Errorf("update dcs[%v] configs failed", id)
+		}
+
+		if count > 20 {
+			break
+		}
+		time.Sleep(5 * time.Second)
+		count++
+	}
+	return fmt.Errorf("after %vs, update dcs[%v] configs is timeout", count*5, id)
+}
+
+type DcsUpdateConfigInputs struct {
+	Inputs []DcsUpdateConfigInput `json:"inputs,omitempty"`
+}
+
+type DcsUpdateConfigInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid    string `json:"guid,omitempty"`
+	Id      string `json:"id,omitempty"`
+	Configs string `json:"configs,omitempty"` //maxmemory-policy=allkeys-lru;timeout=300
+}
+
+type DcsUpdateConfigOutputs struct {
+	Outputs []DcsUpdateConfigOutput `json:"outputs,omitempty"`
+}
+
+type DcsUpdateConfigOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type DcsUpdateConfigAction struct {
+}
+
+func (action *DcsUpdateConfigAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DcsUpdateConfigInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func updateDcsConfig(input DcsUpdateConfigInput) (output DcsUpdateConfigOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	if input.Configs == "" {
+		err = fmt.Errorf("configs is empty")
+		return
+	}
+	values, err := GetMapFromString(input.Configs)
+	if err != nil {
+		return
+	}
+
+	dcsInfo, exist, err := isDcsExist(input.CloudProviderParam, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("dcs[%v] is not exist", input.Id)
+		return
+	}
+	if getDcsEngine(dcsInfo.Engine) != DCS_ENGINE_REDIS {
+		err = fmt.Errorf("dcs[%v] engine is %v, only redis supports configs", input.Id, dcsInfo.Engine)
+		return
+	}
+
+	sc, err := createDcsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	configs, err := getDcsConfigs(sc, input.Id)
+	if err != nil {
+		return
+	}
+
+	currentConfigs := map[string]DcsConfigParam{}
+	for _, config := range configs.RedisConfig {
+		currentConfigs[config.ParamName] = config
+	}
+	updateConfigs := []DcsConfigParam{}
+	for name, value := range values {
+		config, ok := currentConfigs[name]
+		if !ok {
+			err = fmt.Errorf("config(%v) is not supported by dcs[%v]", name, input.Id)
+			return
+		}
+		if config.ParamValue == value {
+			continue
+		}
+		updateConfigs = append(updateConfigs, DcsConfigParam{
+			ParamId:    config.ParamId,
+			ParamName:  name,
+			ParamValue: value,
+		})
+	}
+	if len(updateConfigs) == 0 {
+		logrus.Infof("dcs[%v] configs are already %v", input.Id, input.Configs)
+		return
+	}
+
+	body := map[string]interface{}{
+		"redis_config": updateConfigs,
+	}
+	_, err = sc.Put(sc.ServiceURL("instances", input.Id, "configs"), body, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 204},
+	})
+	if err != nil {
+		logrus.Errorf("update dcs[%v] configs(%++v) meet err=%v", input.Id, updateConfigs, err)
+		return
+	}
+	err = waitDcsConfigOk(sc, input.Id)
+	return
+}
+
+func (action *DcsUpdateConfigAction) Do(inputs interface{}) (interface{}, error) {
+	dcs, _ := inputs.(DcsUpdateConfigInputs)
+	outputs := DcsUpdateConfigOutputs{}
+	var finalErr error
+
+	for _, input := range dcs.Inputs {
+		output, err := updateDcsConfig(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dcs= %v are updated configs", dcs)
+	return &outputs, finalErr
+}
@@ -0,0 +1,190 @@
+package plugins
+
+import (
+	"fmt"
+
+	"github.com/WeBankPartners/wecube-plugins-huaweicloud/plugins/utils"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/dcs/v1/instances"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DCS_PASSWORD_RESULT_OK = "Success"
+)
+
+type DcsPasswordInputs struct {
+	Inputs []DcsPasswordInput `json:"inputs,omitempty"`
+}
+
+type DcsPasswordInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid        string `json:"guid,omitempty"`
+	Seed        string `json:"seed,omitempty"`
+	Id          string `json:"id,omitempty"`
+	OldPassword string `json:"old_password,omitempty"` //only for change-password, could be encrypted
+	Password    string `json:"password,omitempty"`     //new password, empty means random password
+}
+
+type DcsPasswordOutputs struct {
+	Outputs []DcsPasswordOutput `json:"outputs,omitempty"`
+}
+
+type DcsPasswordOutput struct {
+	CallBackParameter
+	Result
+	Guid     string `json:"guid,omitempty"`
+	Id       string `json:"id,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+func checkDcsPasswordParams(input DcsPasswordInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Id == "" {
+		return fmt.Errorf("id is empty")
+	}
+	if input.Seed == "" {
+		return fmt.Errorf("seed is empty")
+	}
+	return nil
+}
+
+// isReset means reset the password without the old password
+func updateDcsPassword(input DcsPasswordInput, isReset bool) (output DcsPasswordOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkDcsPasswordParams(input); err != nil {
+		return
+	}
+	if !isReset && input.OldPassword == "" {
+		err = fmt.Errorf("oldPassword is empty")
+		return
+	}
+
+	_, exist, err := isDcsExist(input.CloudProviderParam, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("dcs[%v] is not exist", input.Id)
+		return
+	}
+
+	sc, err := createDcsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	password := input.Password
+	if password == "" {
+		password = utils.CreateRandomPassword()
+	}
+
+	if isReset {
+		body := map[string]interface{}{
+			"new_password": password,
+		}
+		_, err = sc.Post(sc.ServiceURL("instances", input.Id, "password", "reset"), body, nil, &golangsdk.RequestOpts{
+			OkCodes: []int{200, 204},
+		})
+		if err != nil {
+			logrus.Errorf("reset dcs[%v] password meet err=%v", input.Id, err)
+			return
+		}
+	} else {
+		oldPassword, deErr := utils.AesDePassword(input.Guid, input.Seed, input.OldPassword)
+		if deErr != nil {
+			logrus.Errorf("AesDePassword meet error(%v)", deErr)
+			err = deErr
+			return
+		}
+		opts := instances.UpdatePasswordOpts{
+			OldPassword: oldPassword,
+			NewPassword: password,
+		}
+		resp, updateErr := instances.UpdatePassword(sc, input.Id, opts).Extract()
+		if updateErr != nil {
+			logrus.Errorf("change dcs[%v] password meet err=%v", input.Id, updateErr)
+			err = updateErr
+			return
+		}
+		if resp.Result != DCS_PASSWORD_RESULT_OK {
+			err = fmt.Errorf("change dcs[%v] password failed, result=%v, message=%v", input.Id, resp.Result, resp.Message)
+			return
+		}
+	}
+
+	output.Password, err = utils.AesEnPassword(input.Guid, input.Seed, password, utils.DEFALT_CIPHER)
+	return
+}
+
+//--------------change dcs password------------------//
+type DcsChangePasswordAction struct {
+}
+
+func (action *DcsChangePasswordAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DcsPasswordInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *DcsChangePasswordAction) Do(inputs interface{}) (interface{}, error) {
+	dcs, _ := inputs.(DcsPasswordInputs)
+	outputs := DcsPasswordOutputs{}
+	var finalErr error
+
+	for _, input := range dcs.Inputs {
+		output, err := updateDcsPassword(input, false)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------reset dcs password------------------//
+type DcsResetPasswordAction struct {
+}
+
+func (action *DcsResetPasswordAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DcsPasswordInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *DcsResetPasswordAction) Do(inputs interface{}) (interface{}, error) {
+	dcs, _ := inputs.(DcsPasswordInputs)
+	outputs := DcsPasswordOutputs{}
+	var finalErr error
+
+	for _, input := range dcs.Inputs {
+		output, err := updateDcsPassword(input, true)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
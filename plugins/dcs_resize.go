@@ -0,0 +1,207 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/dcs/v1/instances"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DCS_CHARGING_MODE_PRE_PAID = 1
+)
+
+func waitDcsUpdateOk(sc *golangsdk.ServiceClient, id string, times int, isUpdated func(*instances.Instance) bool) (*instances.Instance, error) {
+	count := 1
+	for {
+		dcsInfo, err := instances.Get(sc, id).Extract()
+		if err != nil {
+			return nil, err
+		}
+		if dcsInfo.Status == "ERROR" {
+			return nil, fmt.Errorf("dcs[%v] status=%v", id, dcsInfo.Status)
+		}
+		if dcsInfo.Status == DCS_STATUS_RUNNING && isUpdated(dcsInfo) {
+			return dcsInfo, nil
+		}
+
+		if count > times {
+			break
+		}
+		time.Sleep(15 * time.Second)
+		count++
+	}
+	return nil, fmt.Errorf("after %vs, update the dcs[%v] is timeout", count*15, id)
+}
+
+// the sdk extend opts have no spec code, which is needed to change the instance type
+type dcsExtendOpts struct {
+	SpecCode    string
+	NewCapacity int
+	IsPrePaid   bool
+}
+
+func (opts dcsExtendOpts) ToExtendMap() (map[string]interface{}, error) {
+	body := map[string]interface{}{
+		"spec_code":    opts.SpecCode,
+		"new_capacity": opts.NewCapacity,
+	}
+	if opts.IsPrePaid {
+		body["bss_param"] = map[string]interface{}{
+			"is_auto_pay": "true",
+		}
+	}
+	return body, nil
+}
+
+type DcsResizeInputs struct {
+	Inputs []DcsResizeInput `json:"inputs,omitempty"`
+}
+
+type DcsResizeInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid         string `json:"guid,omitempty"`
+	Id           string `json:"id,omitempty"`
+	InstanceType string `json:"instance_type,omitempty"` //empty means keep the current edition
+	Capacity     string `json:"capacity,omitempty"`
+}
+
+type DcsResizeOutputs struct {
+	Outputs []DcsResizeOutput `json:"outputs,omitempty"`
+}
+
+type DcsResizeOutput struct {
+	CallBackParameter
+	Result
+	Guid         string `json:"guid,omitempty"`
+	Id           string `json:"id,omitempty"`
+	InstanceType string `json:"instance_type,omitempty"`
+	Capacity     string `json:"capacity,omitempty"`
+	ShardCount   string `json:"shard_count,omitempty"`
+}
+
+type DcsResizeAction struct {
+}
+
+func (action *DcsResizeAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DcsResizeInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkResizeDcsParams(input DcsResizeInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Id == "" {
+		return fmt.Errorf("id is empty")
+	}
+	if _, err := strconv.Atoi(input.Capacity); err != nil {
+		return fmt.Errorf("capacity(%v) invalid", input.Capacity)
+	}
+	return nil
+}
+
+func resizeDcs(input DcsResizeInput) (output DcsResizeOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkResizeDcsParams(input); err != nil {
+		return
+	}
+
+	dcsInfo, exist, err := isDcsExist(input.CloudProviderParam, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("dcs[%v] is not exist", input.Id)
+		return
+	}
+
+	sc, err := createDcsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	currentProduct, err := getDcsProductById(sc, dcsInfo.ProductID)
+	if err != nil {
+		return
+	}
+
+	instanceType := currentProduct.CacheMode
+	if input.InstanceType != "" {
+		instanceType = input.InstanceType
+	}
+	if err = checkDcsInstanceType(currentProduct.Engine, instanceType); err != nil {
+		return
+	}
+	output.InstanceType = instanceType
+	output.Capacity = input.Capacity
+
+	capacity, _ := strconv.Atoi(input.Capacity)
+	if instanceType == currentProduct.CacheMode && capacity == dcsInfo.Capacity {
+		logrus.Infof("dcs[%v] is already %v %vGB", input.Id, instanceType, capacity)
+	} else {
+		product, productErr := getDcsProduct(sc, currentProduct.Engine, instanceType, dcsInfo.EngineVersion, input.Capacity,
+			currentProduct.ChargingType, currentProduct.CpuType)
+		if productErr != nil {
+			err = productErr
+			return
+		}
+
+		opts := dcsExtendOpts{
+			SpecCode:    product.SpecCode,
+			NewCapacity: capacity,
+			IsPrePaid:   dcsInfo.ChargingMode == DCS_CHARGING_MODE_PRE_PAID,
+		}
+		if err = instances.Extend(sc, input.Id, opts).Err; err != nil {
+			logrus.Errorf("resize dcs[%v] to %++v meet err=%v", input.Id, opts, err)
+			return
+		}
+
+		_, err = waitDcsUpdateOk(sc, input.Id, 80, func(instance *instances.Instance) bool {
+			return instance.Capacity == capacity && instance.ProductID == product.ProductID
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	if instanceType == INSTANCE_TYPE_CLUSTER || instanceType == INSTANCE_TYPE_PROXY {
+		output.ShardCount, err = getDcsShardCount(sc, input.Id)
+	}
+	return
+}
+
+func (action *DcsResizeAction) Do(inputs interface{}) (interface{}, error) {
+	dcs, _ := inputs.(DcsResizeInputs)
+	outputs := DcsResizeOutputs{}
+	var finalErr error
+
+	for _, input := range dcs.Inputs {
+		output, err := resizeDcs(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dcs= %v are resized", dcs)
+	return &outputs, finalErr
+}
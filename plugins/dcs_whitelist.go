@@ -0,0 +1,242 @@
+package plugins
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/sirupsen/logrus"
+)
+
+// whitelist api is only provided by dcs v2
+func createDcsServiceClientV2(params CloudProviderParam) (*golangsdk.ServiceClient, error) {
+	sc, err := createDcsServiceClient(params)
+	if err != nil {
+		return nil, err
+	}
+	sc.ResourceBase = sc.Endpoint + "v2/" + sc.ProviderClient.ProjectID + "/"
+	return sc, nil
+}
+
+type DcsWhitelistGroup struct {
+	GroupName string   `json:"group_name"`
+	IpList    []string `json:"ip_list"`
+}
+
+type DcsWhitelist struct {
+	EnableWhitelist bool                `json:"enable_whitelist"`
+	Whitelist       []DcsWhitelistGroup `json:"whitelist"`
+}
+
+func getDcsWhitelist(sc *golangsdk.ServiceClient, id string) (*DcsWhitelist, error) {
+	whitelist := DcsWhitelist{}
+	_, err := sc.Get(sc.ServiceURL("instance", id, "whitelist"), &whitelist, nil)
+	if err != nil {
+		logrus.Errorf("get dcs[%v] whitelist meet err=%v", id, err)
+		return nil, err
+	}
+	return &whitelist, nil
+}
+
+func updateDcsWhitelist(sc *golangsdk.ServiceClient, id string, whitelist *DcsWhitelist) error {
+	// whitelist can't be enabled with no groups
+	whitelist.EnableWhitelist = len(whitelist.Whitelist) > 0
+	if whitelist.Whitelist == nil {
+		whitelist.Whitelist = []DcsWhitelistGroup{}
+	}
+	_, err := sc.Put(sc.ServiceURL("instance", id, "whitelist"), whitelist, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 204},
+	})
+	if err != nil {
+		logrus.Errorf("update dcs[%v] whitelist(%++v) meet err=%v", id, whitelist, err)
+	}
+	return err
+}
+
+func isValidIpOrCidr(value string) error {
+	if net.ParseIP(value) != nil {
+		return nil
+	}
+	return isValidCidr(value)
+}
+
+type DcsWhitelistInputs struct {
+	Inputs []DcsWhitelistInput `json:"inputs,omitempty"`
+}
+
+type DcsWhitelistInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	GroupName string `json:"group_name,omitempty"`
+	Whitelist string `json:"whitelist_ips,omitempty"`
+}
+
+type DcsWhitelistOutputs struct {
+	Outputs []DcsWhitelistOutput `json:"outputs,omitempty"`
+}
+
+type DcsWhitelistOutput struct {
+	CallBackParameter
+	Result
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	Whitelist string `json:"whitelist_ips,omitempty"`
+}
+
+func checkDcsWhitelistParams(input DcsWhitelistInput) ([]string, error) {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return nil, err
+	}
+	if input.Id == "" {
+		return nil, fmt.Errorf("id is empty")
+	}
+	if input.GroupName == "" {
+		return nil, fmt.Errorf("groupName is empty")
+	}
+	if input.Whitelist == "" {
+		return nil, fmt.Errorf("whitelist is empty")
+	}
+	list, err := GetArrayFromString(input.Whitelist, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range list {
+		if err := isValidIpOrCidr(ip); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// isAdd means merge the input into the group, otherwise cull the input from the group
+func changeDcsWhitelist(input DcsWhitelistInput, isAdd bool) (output DcsWhitelistOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	inputList, err := checkDcsWhitelistParams(input)
+	if err != nil {
+		return
+	}
+
+	_, exist, err := isDcsExist(input.CloudProviderParam, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("dcs[%v] is not exist", input.Id)
+		return
+	}
+
+	sc, err := createDcsServiceClientV2(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	whitelist, err := getDcsWhitelist(sc, input.Id)
+	if err != nil {
+		return
+	}
+
+	groups := []DcsWhitelistGroup{}
+	found := false
+	for _, group := range whitelist.Whitelist {
+		if group.GroupName == input.GroupName {
+			found = true
+			if isAdd {
+				group.IpList = MergeTwoArraysString(group.IpList, inputList)
+			} else {
+				group.IpList = CullTwoArraysString(group.IpList, inputList)
+			}
+			output.Whitelist = strings.Join(group.IpList, ",")
+			// drop the empty group
+			if len(group.IpList) == 0 {
+				continue
+			}
+		}
+		groups = append(groups, group)
+	}
+	if !found {
+		if !isAdd {
+			logrus.Infof("dcs[%v] whitelist group(%v) is not exist", input.Id, input.GroupName)
+			return
+		}
+		groups = append(groups, DcsWhitelistGroup{
+			GroupName: input.GroupName,
+			IpList:    inputList,
+		})
+		output.Whitelist = strings.Join(inputList, ",")
+	}
+
+	whitelist.Whitelist = groups
+	err = updateDcsWhitelist(sc, input.Id, whitelist)
+	return
+}
+
+//--------------add dcs whitelist------------------//
+type DcsAddWhitelistAction struct {
+}
+
+func (action *DcsAddWhitelistAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DcsWhitelistInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *DcsAddWhitelistAction) Do(inputs interface{}) (interface{}, error) {
+	dcs, _ := inputs.(DcsWhitelistInputs)
+	outputs := DcsWhitelistOutputs{}
+	var finalErr error
+
+	for _, input := range dcs.Inputs {
+		output, err := changeDcsWhitelist(input, true)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------remove dcs whitelist------------------//
+type DcsRemoveWhitelistAction struct {
+}
+
+func (action *DcsRemoveWhitelistAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DcsWhitelistInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *DcsRemoveWhitelistAction) Do(inputs interface{}) (interface{}, error) {
+	dcs, _ := inputs.(DcsWhitelistInputs)
+	outputs := DcsWhitelistOutputs{}
+	var finalErr error
+
+	for _, input := range dcs.Inputs {
+		output, err := changeDcsWhitelist(input, false)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
@@ -0,0 +1,715 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WeBankPartners/wecube-plugins-huaweicloud/plugins/utils"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack"
+	"github.com/huaweicloud/golangsdk/openstack/dds/v3/flavors"
+	"github.com/huaweicloud/golangsdk/openstack/dds/v3/instances"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DDS_MODE_SINGLE   = "single"
+	DDS_MODE_REPLICA  = "replica_set"
+	DDS_MODE_SHARDING = "sharding"
+
+	DDS_DATASTORE_TYPE     = "DDS-Community"
+	DDS_STORAGE_ENGINE     = "wiredTiger"
+	DDS_DEFAULT_VERSION    = "3.4"
+	DDS_DEFAULT_NODE_NUM   = 2
+	DDS_CONFIG_VOLUME_SIZE = 20
+
+	DDS_STATUS_NORMAL      = "normal"
+	DDS_STATUS_CREATE_FAIL = "createfail"
+)
+
+var ddsModeMap = map[string]string{
+	DDS_MODE_SINGLE:   "Single",
+	DDS_MODE_REPLICA:  "ReplicaSet",
+	DDS_MODE_SHARDING: "Sharding",
+}
+
+func createDdsServiceClient(params CloudProviderParam) (*golangsdk.ServiceClient, error) {
+	client, err := createGolangSdkProviderClient(params)
+	if err != nil {
+		return nil, err
+	}
+	cloudMap, _ := GetMapFromString(params.CloudParams)
+	sc, err := openstack.NewDDSV3(client, golangsdk.EndpointOpts{
+		Region: cloudMap[CLOUD_PARAM_REGION],
+	})
+	if err != nil {
+		logrus.Errorf("createDdsServiceClient meet err=%v", err)
+		return nil, err
+	}
+	return sc, err
+}
+
+var ddsActions = make(map[string]Action)
+
+func init() {
+	ddsActions["create"] = new(DdsCreateAction)
+	ddsActions["delete"] = new(DdsDeleteAction)
+	ddsActions["query"] = new(DdsQueryAction)
+}
+
+type DdsPlugin struct {
+}
+
+func (plugin *DdsPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := ddsActions[actionName]
+	if !found {
+		logrus.Errorf("dds plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("dds plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func getDdsInstanceById(sc *golangsdk.ServiceClient, id string) (*instances.InstanceResponse, bool, error) {
+	allPages, err := instances.List(sc, instances.ListInstanceOpts{Id: id}).AllPages()
+	if err != nil {
+		logrus.Errorf("list dds[%v] meet err=%v", id, err)
+		return nil, false, err
+	}
+	resp, err := instances.ExtractInstances(allPages)
+	if err != nil {
+		return nil, false, err
+	}
+	for i, instance := range resp.Instances {
+		if instance.Id == id {
+			return &resp.Instances[i], true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// mongos of sharding, primary of replica set or the node of single
+func getDdsPrivateIp(instance *instances.InstanceResponse) string {
+	ips := []string{}
+	for _, group := range instance.Groups {
+		for _, node := range group.Nodes {
+			if node.PrivateIP == "" {
+				continue
+			}
+			switch instance.Mode {
+			case ddsModeMap[DDS_MODE_SHARDING]:
+				if group.Type == "mongos" {
+					ips = append(ips, node.PrivateIP)
+				}
+			case ddsModeMap[DDS_MODE_REPLICA]:
+				if node.Role == "Primary" {
+					ips = append(ips, node.PrivateIP)
+				}
+			default:
+				ips = append(ips, node.PrivateIP)
+			}
+		}
+	}
+	return strings.Join(ips, ",")
+}
+
+func waitDdsCreateOk(sc *golangsdk.ServiceClient, id string) (*instances.InstanceResponse, error) {
+	count := 1
+	for {
+		instance, exist, err := getDdsInstanceById(sc, id)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			if instance.Status == DDS_STATUS_CREATE_FAIL {
+				return nil, fmt.Errorf("create dds[%v] status=%v", id, instance.Status)
+			}
+			if instance.Status == DDS_STATUS_NORMAL {
+				return instance, nil
+			}
+		}
+
+		if count > 120 {
+			break
+		}
+		time.Sleep(15 * time.Second)
+		count++
+	}
+	return nil, fmt.Errorf("after %vs, create dds[%v] is timeout", count*15, id)
+}
+
+func waitDdsDeleteOk(sc *golangsdk.ServiceClient, id string) error {
+	count := 1
+	for {
+		_, exist, err := getDdsInstanceById(sc, id)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return nil
+		}
+
+		if count > 40 {
+			break
+		}
+		time.Sleep(15 * time.Second)
+		count++
+	}
+	return fmt.Errorf("after %vs, delete dds[%v] is timeout", count*15, id)
+}
+
+//--------------create dds------------------//
+type DdsCreateInputs struct {
+	Inputs []DdsCreateInput `json:"inputs,omitempty"`
+}
+
+type DdsCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid          string `json:"guid,omitempty"`
+	Seed          string `json:"seed,omitempty"`
+	Id            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	EngineVersion string `json:"engine_version,omitempty"` //3.4 or 4.0, default 3.4
+	Mode          string `json:"mode,omitempty"`           //single, replica_set or sharding
+	MachineSpec   string `json:"machine_spec,omitempty"`   //2C4G
+	Password      string `json:"password,omitempty"`
+	VolumeType    string `json:"volume_type,omitempty"`
+	VolumeSize    string `json:"volume_size,omitempty"`
+	ShardNum      string `json:"shard_num,omitempty"`  //only for sharding, default 2
+	MongosNum     string `json:"mongos_num,omitempty"` //only for sharding, default 2
+
+	VpcId           string `json:"vpc_id,omitempty"`
+	SubnetId        string `json:"subnet_id,omitempty"`
+	SecurityGroupId string `json:"security_group_id,omitempty"`
+	AvailableZone   string `json:"az,omitempty"`
+	ChargeType      string `json:"charge_type,omitempty"`
+
+	//包年包月
+	PeriodType  string `json:"period_type,omitempty"`   //年或月
+	PeriodNum   string `json:"period_num,omitempty"`    //月有效值[1-9],年有效值[1-3]
+	IsAutoRenew string `json:"is_auto_renew,omitempty"` //是否自动续费
+}
+
+type DdsCreateOutputs struct {
+	Outputs []DdsCreateOutput `json:"outputs,omitempty"`
+}
+
+type DdsCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	PrivateIp string `json:"private_ip,omitempty"`
+	Port      string `json:"port,omitempty"`
+	UserName  string `json:"user_name,omitempty"`
+	Password  string `json:"password,omitempty"`
+}
+
+type DdsCreateAction struct {
+}
+
+func (action *DdsCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DdsCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkCreateDdsParams(input DdsCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Seed == "" {
+		return fmt.Errorf("empty seed")
+	}
+	if input.Name == "" {
+		return fmt.Errorf("empty name")
+	}
+	if err := isValidStringValue("mode", input.Mode, []string{DDS_MODE_SINGLE, DDS_MODE_REPLICA, DDS_MODE_SHARDING}); err != nil {
+		return err
+	}
+	if input.EngineVersion != "" {
+		if err := isValidStringValue("engineVersion", input.EngineVersion, []string{"3.2", "3.4", "4.0"}); err != nil {
+			return err
+		}
+	}
+	if _, _, err := getCpuAndMemoryFromHostType(input.MachineSpec); err != nil {
+		return err
+	}
+	if _, err := isValidInteger(input.VolumeSize, 10, 3000); err != nil {
+		return fmt.Errorf("volumeSize(%v) invalid", input.VolumeSize)
+	}
+	if input.Mode == DDS_MODE_SHARDING {
+		if input.ShardNum != "" {
+			if _, err := isValidInteger(input.ShardNum, 2, 16); err != nil {
+				return fmt.Errorf("shardNum(%v) invalid", input.ShardNum)
+			}
+		}
+		if input.MongosNum != "" {
+			if _, err := isValidInteger(input.MongosNum, 2, 16); err != nil {
+				return fmt.Errorf("mongosNum(%v) invalid", input.MongosNum)
+			}
+		}
+	}
+
+	if input.VpcId == "" {
+		return fmt.Errorf("empty vpcId")
+	}
+	if input.SubnetId == "" {
+		return fmt.Errorf("empty subnetId")
+	}
+	if input.SecurityGroupId == "" {
+		return fmt.Errorf("empty securityGroupId")
+	}
+	if input.AvailableZone == "" {
+		return fmt.Errorf("empty az")
+	}
+
+	if err := isValidStringValue("chargeType", input.ChargeType, []string{PRE_PAID, POST_PAID}); err != nil {
+		return err
+	}
+	if input.ChargeType == PRE_PAID {
+		if err := isValidStringValue("periodType", input.PeriodType, []string{PRE_PAID_MONTH, PRE_PAID_YEAR}); err != nil {
+			return err
+		}
+		maxPeriodNum := int64(9)
+		if input.PeriodType == PRE_PAID_YEAR {
+			maxPeriodNum = 3
+		}
+		if _, err := isValidInteger(input.PeriodNum, 1, maxPeriodNum); err != nil {
+			return fmt.Errorf("periodNum is invalid, %v", err)
+		}
+	}
+	return nil
+}
+
+// find the smallest flavor of the node type which could satisfy the cpu and memory in the az
+func getDdsFlavorSpecCode(ddsFlavors []flavors.Flavor, flavorType string, cpu int64, memory int64, az string) (string, error) {
+	specCode := ""
+	var minCpu, minMemory int64
+	for _, flavor := range ddsFlavors {
+		if flavor.Type != flavorType {
+			continue
+		}
+		if err := isValidStringValue("az", az, flavor.AvailabilityZone); err != nil {
+			continue
+		}
+		vcpus, err := strconv.ParseInt(flavor.Vcpus, 10, 64)
+		if err != nil {
+			continue
+		}
+		ram, err := strconv.ParseInt(flavor.Ram, 10, 64)
+		if err != nil {
+			continue
+		}
+		if vcpus < cpu || ram < memory {
+			continue
+		}
+		if specCode == "" || vcpus < minCpu || (vcpus == minCpu && ram < minMemory) {
+			specCode = flavor.SpecCode
+			minCpu = vcpus
+			minMemory = ram
+		}
+	}
+	if specCode == "" {
+		return "", fmt.Errorf("could not get suitable dds %v flavor for %vC%vG in az(%v)", flavorType, cpu, memory, az)
+	}
+	return specCode, nil
+}
+
+func buildDdsFlavors(sc *golangsdk.ServiceClient, input DdsCreateInput, region string) ([]instances.Flavor, error) {
+	cpu, memory, _ := getCpuAndMemoryFromHostType(input.MachineSpec)
+	size, _ := strconv.Atoi(input.VolumeSize)
+
+	allPages, err := flavors.List(sc, flavors.ListOpts{
+		Region:     region,
+		EngineName: DDS_DATASTORE_TYPE,
+	}).AllPages()
+	if err != nil {
+		logrus.Errorf("list dds flavors meet err=%v", err)
+		return nil, err
+	}
+	ddsFlavors, err := flavors.ExtractFlavors(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Mode != DDS_MODE_SHARDING {
+		flavorType := "single"
+		if input.Mode == DDS_MODE_REPLICA {
+			flavorType = "replica"
+		}
+		specCode, err := getDdsFlavorSpecCode(ddsFlavors, flavorType, cpu, memory, input.AvailableZone)
+		if err != nil {
+			return nil, err
+		}
+		return []instances.Flavor{{
+			Type:     flavorType,
+			Num:      1,
+			Storage:  input.VolumeType,
+			Size:     size,
+			SpecCode: specCode,
+		}}, nil
+	}
+
+	shardNum, mongosNum := DDS_DEFAULT_NODE_NUM, DDS_DEFAULT_NODE_NUM
+	if input.ShardNum != "" {
+		shardNum, _ = strconv.Atoi(input.ShardNum)
+	}
+	if input.MongosNum != "" {
+		mongosNum, _ = strconv.Atoi(input.MongosNum)
+	}
+	mongosSpecCode, err := getDdsFlavorSpecCode(ddsFlavors, "mongos", cpu, memory, input.AvailableZone)
+	if err != nil {
+		return nil, err
+	}
+	shardSpecCode, err := getDdsFlavorSpecCode(ddsFlavors, "shard", cpu, memory, input.AvailableZone)
+	if err != nil {
+		return nil, err
+	}
+	// config node is not the bottleneck, use the smallest one
+	configSpecCode, err := getDdsFlavorSpecCode(ddsFlavors, "config", 0, 0, input.AvailableZone)
+	if err != nil {
+		return nil, err
+	}
+	return []instances.Flavor{
+		{Type: "mongos", Num: mongosNum, SpecCode: mongosSpecCode},
+		{Type: "shard", Num: shardNum, Storage: input.VolumeType, Size: size, SpecCode: shardSpecCode},
+		{Type: "config", Num: 1, Storage: input.VolumeType, Size: DDS_CONFIG_VOLUME_SIZE, SpecCode: configSpecCode},
+	}, nil
+}
+
+// CreateOpts of the sdk has no charge info, so add it to the request body
+type ddsCreateOpts map[string]interface{}
+
+func (opts ddsCreateOpts) ToInstancesCreateMap() (map[string]interface{}, error) {
+	return opts, nil
+}
+
+func buildDdsCreateOpts(opts instances.CreateOpts, input DdsCreateInput) (ddsCreateOpts, error) {
+	body, err := opts.ToInstancesCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if input.ChargeType == PRE_PAID {
+		periodNum, _ := strconv.Atoi(input.PeriodNum)
+		body["charge_info"] = map[string]interface{}{
+			"charge_mode":   PRE_PAID,
+			"period_type":   input.PeriodType,
+			"period_num":    periodNum,
+			"is_auto_renew": strings.ToLower(input.IsAutoRenew) == "true",
+			"is_auto_pay":   true,
+		}
+	}
+	return ddsCreateOpts(body), nil
+}
+
+func createDds(input DdsCreateInput) (output DdsCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkCreateDdsParams(input); err != nil {
+		return
+	}
+
+	sc, err := createDdsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	if input.Id != "" {
+		instance, exist, getErr := getDdsInstanceById(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			output.PrivateIp = getDdsPrivateIp(instance)
+			output.Port = instance.Port
+			output.UserName = instance.DbUserName
+			return
+		}
+	}
+
+	if input.EngineVersion == "" {
+		input.EngineVersion = DDS_DEFAULT_VERSION
+	}
+	if input.VolumeType == "" {
+		input.VolumeType = "ULTRAHIGH"
+	}
+	cloudMap, _ := GetMapFromString(input.CloudParams)
+	ddsFlavors, err := buildDdsFlavors(sc, input, cloudMap[CLOUD_PARAM_REGION])
+	if err != nil {
+		return
+	}
+	if input.Password == "" {
+		input.Password = utils.CreateRandomPassword()
+	}
+
+	opts := instances.CreateOpts{
+		Name: input.Name,
+		DataStore: instances.DataStore{
+			Type:          DDS_DATASTORE_TYPE,
+			Version:       input.EngineVersion,
+			StorageEngine: DDS_STORAGE_ENGINE,
+		},
+		Region:           cloudMap[CLOUD_PARAM_REGION],
+		AvailabilityZone: input.AvailableZone,
+		VpcId:            input.VpcId,
+		SubnetId:         input.SubnetId,
+		SecurityGroupId:  input.SecurityGroupId,
+		Password:         input.Password,
+		Mode:             ddsModeMap[input.Mode],
+		Flavor:           ddsFlavors,
+	}
+	createOpts, err := buildDdsCreateOpts(opts, input)
+	if err != nil {
+		return
+	}
+
+	logrus.Infof("dds opts=%++v", opts.Flavor)
+	resp, err := instances.Create(sc, createOpts).Extract()
+	if err != nil {
+		logrus.Errorf("create dds meet err=%v", err)
+		return
+	}
+	output.Id = resp.Id
+
+	instance, err := waitDdsCreateOk(sc, resp.Id)
+	if err != nil {
+		return
+	}
+	output.PrivateIp = getDdsPrivateIp(instance)
+	output.Port = instance.Port
+	output.UserName = instance.DbUserName
+	output.Password, err = utils.AesEnPassword(input.Guid, input.Seed, input.Password, utils.DEFALT_CIPHER)
+	return
+}
+
+func (action *DdsCreateAction) Do(inputs interface{}) (interface{}, error) {
+	dds, _ := inputs.(DdsCreateInputs)
+	outputs := DdsCreateOutputs{}
+	var finalErr error
+
+	for _, input := range dds.Inputs {
+		output, err := createDds(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dds= %v are created", dds)
+	return &outputs, finalErr
+}
+
+//--------------delete dds------------------//
+type DdsDeleteInputs struct {
+	Inputs []DdsDeleteInput `json:"inputs,omitempty"`
+}
+
+type DdsDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type DdsDeleteOutputs struct {
+	Outputs []DdsDeleteOutput `json:"outputs,omitempty"`
+}
+
+type DdsDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type DdsDeleteAction struct {
+}
+
+func (action *DdsDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DdsDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteDds(input DdsDeleteInput) (output DdsDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createDdsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	_, exist, err := getDdsInstanceById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	if _, err = instances.Delete(sc, input.Id).Extract(); err != nil {
+		logrus.Errorf("delete dds[%v] meet err=%v", input.Id, err)
+		return
+	}
+	err = waitDdsDeleteOk(sc, input.Id)
+	return
+}
+
+func (action *DdsDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	dds, _ := inputs.(DdsDeleteInputs)
+	outputs := DdsDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range dds.Inputs {
+		output, err := deleteDds(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dds= %v are delete", dds)
+	return &outputs, finalErr
+}
+
+//--------------query dds------------------//
+type DdsQueryInputs struct {
+	Inputs []DdsQueryInput `json:"inputs,omitempty"`
+}
+
+type DdsQueryInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type DdsQueryOutputs struct {
+	Outputs []DdsQueryOutput `json:"outputs,omitempty"`
+}
+
+type DdsQueryOutput struct {
+	CallBackParameter
+	Result
+	Guid            string `json:"guid,omitempty"`
+	Id              string `json:"id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Status          string `json:"status,omitempty"`
+	Mode            string `json:"mode,omitempty"`
+	EngineVersion   string `json:"engine_version,omitempty"`
+	PrivateIp       string `json:"private_ip,omitempty"`
+	Port            string `json:"port,omitempty"`
+	UserName        string `json:"user_name,omitempty"`
+	VpcId           string `json:"vpc_id,omitempty"`
+	SubnetId        string `json:"subnet_id,omitempty"`
+	SecurityGroupId string `json:"security_group_id,omitempty"`
+}
+
+type DdsQueryAction struct {
+}
+
+func (action *DdsQueryAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DdsQueryInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func queryDds(input DdsQueryInput) (output DdsQueryOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createDdsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	instance, exist, err := getDdsInstanceById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("dds[%v] is not exist", input.Id)
+		return
+	}
+
+	output.Name = instance.Name
+	output.Status = instance.Status
+	for mode, ddsMode := range ddsModeMap {
+		if ddsMode == instance.Mode {
+			output.Mode = mode
+		}
+	}
+	output.EngineVersion = instance.DataStore.Version
+	output.PrivateIp = getDdsPrivateIp(instance)
+	output.Port = instance.Port
+	output.UserName = instance.DbUserName
+	output.VpcId = instance.VpcId
+	output.SubnetId = instance.SubnetId
+	output.SecurityGroupId = instance.SecurityGroupId
+	return
+}
+
+func (action *DdsQueryAction) Do(inputs interface{}) (interface{}, error) {
+	dds, _ := inputs.(DdsQueryInputs)
+	outputs := DdsQueryOutputs{}
+	var finalErr error
+
+	for _, input := range dds.Inputs {
+		output, err := queryDds(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
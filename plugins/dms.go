@@ -0,0 +1,684 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WeBankPartners/wecube-plugins-huaweicloud/plugins/utils"
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack"
+	"github.com/huaweicloud/golangsdk/openstack/dms/v1/availablezones"
+	"github.com/huaweicloud/golangsdk/openstack/dms/v1/instances"
+	"github.com/huaweicloud/golangsdk/openstack/dms/v1/products"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DMS_ENGINE_KAFKA    = "kafka"
+	DMS_ENGINE_RABBITMQ = "rabbitmq"
+
+	DMS_STATUS_RUNNING     = "RUNNING"
+	DMS_STATUS_CREATE_FAIL = "CREATEFAILED"
+)
+
+func createDmsServiceClient(params CloudProviderParam) (*golangsdk.ServiceClient, error) {
+	client, err := createGolangSdkProviderClient(params)
+	if err != nil {
+		return nil, err
+	}
+	cloudMap, _ := GetMapFromString(params.CloudParams)
+	sc, err := openstack.NewDMSServiceV1(client, golangsdk.EndpointOpts{
+		Region: cloudMap[CLOUD_PARAM_REGION],
+	})
+	if err != nil {
+		logrus.Errorf("createDmsServiceClient meet err=%v", err)
+		return nil, err
+	}
+	return sc, err
+}
+
+var dmsActions = make(map[string]Action)
+
+func init() {
+	dmsActions["create"] = new(DmsCreateAction)
+	dmsActions["delete"] = new(DmsDeleteAction)
+	dmsActions["query"] = new(DmsQueryAction)
+}
+
+type DmsPlugin struct {
+}
+
+func (plugin *DmsPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := dmsActions[actionName]
+	if !found {
+		logrus.Errorf("dms plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("dms plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func isDmsExist(sc *golangsdk.ServiceClient, id string) (*instances.Instance, bool, error) {
+	allPages, err := instances.List(sc, instances.ListDmsInstanceOpts{
+		Id:             id,
+		IncludeFailure: "true",
+	}).AllPages()
+	if err != nil {
+		logrus.Errorf("list dms[%v] meet err=%v", id, err)
+		return nil, false, err
+	}
+	resp, err := instances.ExtractDmsInstances(allPages)
+	if err != nil {
+		return nil, false, err
+	}
+	for i, instance := range resp.Instances {
+		if instance.InstanceID == id {
+			return &resp.Instances[i], true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func waitDmsCreateOk(sc *golangsdk.ServiceClient, id string) (*instances.Instance, error) {
+	count := 1
+	for {
+		dmsInfo, err := instances.Get(sc, id).Extract()
+		if err != nil {
+			return nil, err
+		}
+		if dmsInfo.Status == DMS_STATUS_CREATE_FAIL || dmsInfo.Status == "ERROR" {
+			return nil, fmt.Errorf("create dms[%v] status=%v", id, dmsInfo.Status)
+		}
+		if dmsInfo.Status == DMS_STATUS_RUNNING {
+			return dmsInfo, nil
+		}
+
+		if count > 80 {
+			break
+		}
+		time.Sleep(15 * time.Second)
+		count++
+	}
+	return nil, fmt.Errorf("after %vs, create dms[%v] is timeout", count*15, id)
+}
+
+func waitDmsDeleteOk(sc *golangsdk.ServiceClient, id string) error {
+	count := 1
+	for {
+		_, exist, err := isDmsExist(sc, id)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return nil
+		}
+
+		if count > 40 {
+			break
+		}
+		time.Sleep(15 * time.Second)
+		count++
+	}
+	return fmt.Errorf("after %vs, delete dms[%v] is timeout", count*15, id)
+}
+
+//--------------create dms------------------//
+type DmsCreateInputs struct {
+	Inputs []DmsCreateInput `json:"inputs,omitempty"`
+}
+
+type DmsCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid          string `json:"guid,omitempty"`
+	Seed          string `json:"seed,omitempty"`
+	Id            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Engine        string `json:"engine,omitempty"` //kafka or rabbitmq
+	EngineVersion string `json:"engine_version,omitempty"`
+	InstanceType  string `json:"instance_type,omitempty"` //single or cluster, kafka only supports cluster
+	SpecCode      string `json:"spec_code,omitempty"`
+	StorageSpace  string `json:"storage_space,omitempty"`
+	IoType        string `json:"io_type,omitempty"` //empty means the first io type of the product
+	AccessUser    string `json:"access_user,omitempty"`
+	Password      string `json:"password,omitempty"`
+
+	VpcId           string `json:"vpc_id,omitempty"`
+	SubnetId        string `json:"subnet_id,omitempty"`
+	SecurityGroupId string `json:"security_group_id,omitempty"`
+	AvailableZones  string `json:"az,omitempty"`
+	ChargeType      string `json:"charge_type,omitempty"`
+
+	//包年包月
+	PeriodType  string `json:"period_type,omitempty"`   //年或月
+	PeriodNum   string `json:"period_num,omitempty"`    //月有效值[1-9],年有效值[1-3]
+	IsAutoRenew string `json:"is_auto_renew,omitempty"` //是否自动续费
+}
+
+type DmsCreateOutputs struct {
+	Outputs []DmsCreateOutput `json:"outputs,omitempty"`
+}
+
+type DmsCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	PrivateIp  string `json:"private_ip,omitempty"`
+	Port       string `json:"port,omitempty"`
+	AccessUser string `json:"access_user,omitempty"`
+	Password   string `json:"password,omitempty"`
+}
+
+type DmsCreateAction struct {
+}
+
+func (action *DmsCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DmsCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkCreateDmsParams(input DmsCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Seed == "" {
+		return fmt.Errorf("empty seed")
+	}
+	if input.Name == "" {
+		return fmt.Errorf("empty name")
+	}
+	if err := isValidStringValue("engine", input.Engine, []string{DMS_ENGINE_KAFKA, DMS_ENGINE_RABBITMQ}); err != nil {
+		return err
+	}
+	validInstanceTypes := []string{INSTANCE_TYPE_SINGLE, INSTANCE_TYPE_CLUSTER}
+	if input.Engine == DMS_ENGINE_KAFKA {
+		validInstanceTypes = []string{INSTANCE_TYPE_CLUSTER}
+	}
+	if err := isValidStringValue("instanceType", input.InstanceType, validInstanceTypes); err != nil {
+		return err
+	}
+	if input.SpecCode == "" {
+		return fmt.Errorf("empty specCode")
+	}
+	if _, err := strconv.Atoi(input.StorageSpace); err != nil {
+		return fmt.Errorf("storageSpace(%v) invalid", input.StorageSpace)
+	}
+	if input.Engine == DMS_ENGINE_RABBITMQ && input.AccessUser == "" {
+		return fmt.Errorf("empty accessUser")
+	}
+
+	if input.VpcId == "" {
+		return fmt.Errorf("empty vpcId")
+	}
+	if input.SubnetId == "" {
+		return fmt.Errorf("empty subnetId")
+	}
+	if input.SecurityGroupId == "" {
+		return fmt.Errorf("empty securityGroupId")
+	}
+	if input.AvailableZones == "" {
+		return fmt.Errorf("empty az")
+	}
+
+	if err := isValidStringValue("chargeType", input.ChargeType, []string{PRE_PAID, POST_PAID}); err != nil {
+		return err
+	}
+	if input.ChargeType == PRE_PAID {
+		if err := isValidStringValue("periodType", input.PeriodType, []string{PRE_PAID_MONTH, PRE_PAID_YEAR}); err != nil {
+			return err
+		}
+		maxPeriodNum := int64(9)
+		if input.PeriodType == PRE_PAID_YEAR {
+			maxPeriodNum = 3
+		}
+		if _, err := isValidInteger(input.PeriodNum, 1, maxPeriodNum); err != nil {
+			return fmt.Errorf("periodNum is invalid, %v", err)
+		}
+	}
+	return nil
+}
+
+type DmsProduct struct {
+	ProductId       string
+	StorageSpecCode string
+	Specification   string
+	PartitionNum    int
+}
+
+func getDmsStorageSpecCode(ios []products.IO, ioType string) (string, error) {
+	for _, io := range ios {
+		if ioType == "" || io.IOType == ioType {
+			return io.StorageSpecCode, nil
+		}
+	}
+	return "", fmt.Errorf("can't find the io type(%v)", ioType)
+}
+
+// find the product by engine, version, instance type, spec code and charging type
+func getDmsProduct(sc *golangsdk.ServiceClient, input DmsCreateInput) (*DmsProduct, error) {
+	response, err := products.Get(sc, input.Engine).Extract()
+	if err != nil {
+		logrus.Errorf("get dms products meet err=%v", err)
+		return nil, err
+	}
+
+	// dms products are only divided into hourly and monthly
+	parameters := response.Hourly
+	if input.ChargeType == PRE_PAID {
+		parameters = response.Monthly
+	}
+
+	for _, parameter := range parameters {
+		if !strings.EqualFold(parameter.Name, input.Engine) {
+			continue
+		}
+		if input.EngineVersion != "" && parameter.Version != input.EngineVersion {
+			continue
+		}
+		for _, value := range parameter.Values {
+			if value.Name != input.InstanceType {
+				continue
+			}
+			for _, detail := range value.Details {
+				if detail.SpecCode == input.SpecCode {
+					storageSpecCode, err := getDmsStorageSpecCode(detail.IOs, input.IoType)
+					if err != nil {
+						return nil, err
+					}
+					partitionNum, _ := strconv.Atoi(detail.PartitionNum)
+					return &DmsProduct{
+						ProductId:       detail.ProductID,
+						StorageSpecCode: storageSpecCode,
+						Specification:   detail.Bandwidth,
+						PartitionNum:    partitionNum,
+					}, nil
+				}
+				for _, info := range detail.ProductInfos {
+					if info.SpecCode != input.SpecCode {
+						continue
+					}
+					storageSpecCode, err := getDmsStorageSpecCode(info.IOs, input.IoType)
+					if err != nil {
+						return nil, err
+					}
+					return &DmsProduct{
+						ProductId:       info.ProductID,
+						StorageSpecCode: storageSpecCode,
+					}, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("can't find the desire %v product of specCode(%v)", input.Engine, input.SpecCode)
+}
+
+// CreateOps of the sdk has no bss param, so add it to the request body
+type dmsCreateOpts map[string]interface{}
+
+func (opts dmsCreateOpts) ToInstanceCreateMap() (map[string]interface{}, error) {
+	return opts, nil
+}
+
+func buildDmsCreateOpts(opts instances.CreateOps, input DmsCreateInput) (dmsCreateOpts, error) {
+	body, err := opts.ToInstanceCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if input.ChargeType == PRE_PAID {
+		periodNum, _ := strconv.Atoi(input.PeriodNum)
+		body["bss_param"] = map[string]interface{}{
+			"charging_mode": PRE_PAID,
+			"period_type":   input.PeriodType,
+			"period_num":    periodNum,
+			"is_auto_renew": strings.ToLower(input.IsAutoRenew) == "true",
+			"is_auto_pay":   true,
+		}
+	}
+	return dmsCreateOpts(body), nil
+}
+
+func getDmsAvailableAzMap(sc *golangsdk.ServiceClient) (map[string]string, error) {
+	azMap := make(map[string]string)
+	response, err := availablezones.Get(sc).Extract()
+	if err != nil {
+		logrus.Errorf("get dms available zones meet err=%v", err)
+		return azMap, err
+	}
+	for _, az := range response.AvailableZones {
+		if az.ResourceAvailability == "true" {
+			azMap[az.Code] = az.ID
+		}
+	}
+	return azMap, nil
+}
+
+func createDms(input DmsCreateInput) (output DmsCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkCreateDmsParams(input); err != nil {
+		return
+	}
+
+	sc, err := createDmsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	if input.Id != "" {
+		dmsInfo, exist, getErr := isDmsExist(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			output.PrivateIp = dmsInfo.ConnectAddress
+			output.Port = fmt.Sprintf("%v", dmsInfo.Port)
+			output.AccessUser = input.AccessUser
+			return
+		}
+	}
+
+	azs, err := GetArrayFromString(input.AvailableZones, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+	azMap, err := getDmsAvailableAzMap(sc)
+	if err != nil {
+		return
+	}
+	azCodes := []string{}
+	for _, az := range azs {
+		if _, ok := azMap[az]; !ok {
+			err = fmt.Errorf("az(%v) is unavailable,please use %++v", az, azMap)
+			return
+		}
+		azCodes = append(azCodes, azMap[az])
+	}
+
+	product, err := getDmsProduct(sc, input)
+	if err != nil {
+		return
+	}
+
+	storageSpace, _ := strconv.Atoi(input.StorageSpace)
+	opts := instances.CreateOps{
+		Name:            input.Name,
+		Engine:          input.Engine,
+		EngineVersion:   input.EngineVersion,
+		StorageSpace:    storageSpace,
+		VPCID:           input.VpcId,
+		SecurityGroupID: input.SecurityGroupId,
+		SubnetID:        input.SubnetId,
+		AvailableZones:  azCodes,
+		ProductID:       product.ProductId,
+		StorageSpecCode: product.StorageSpecCode,
+	}
+	if input.Engine == DMS_ENGINE_KAFKA {
+		opts.Specification = product.Specification
+		opts.PartitionNum = product.PartitionNum
+		// kafka only needs the user when ssl is enabled
+		opts.SslEnable = input.AccessUser != ""
+	}
+	if input.AccessUser != "" {
+		if input.Password == "" {
+			input.Password = utils.CreateRandomPassword()
+		}
+		opts.AccessUser = input.AccessUser
+		opts.Password = input.Password
+	}
+	createOpts, err := buildDmsCreateOpts(opts, input)
+	if err != nil {
+		return
+	}
+
+	logrus.Infof("dms opts=%++v", opts)
+	resp, err := instances.Create(sc, createOpts).Extract()
+	if err != nil {
+		logrus.Errorf("create dms meet err=%v", err)
+		return
+	}
+	output.Id = resp.InstanceID
+
+	dmsInfo, err := waitDmsCreateOk(sc, resp.InstanceID)
+	if err != nil {
+		return
+	}
+	output.PrivateIp = dmsInfo.ConnectAddress
+	output.Port = fmt.Sprintf("%v", dmsInfo.Port)
+	output.AccessUser = input.AccessUser
+	if input.Password != "" {
+		output.Password, err = utils.AesEnPassword(input.Guid, input.Seed, input.Password, utils.DEFALT_CIPHER)
+	}
+	return
+}
+
+func (action *DmsCreateAction) Do(inputs interface{}) (interface{}, error) {
+	dms, _ := inputs.(DmsCreateInputs)
+	outputs := DmsCreateOutputs{}
+	var finalErr error
+
+	for _, input := range dms.Inputs {
+		output, err := createDms(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dms= %v are created", dms)
+	return &outputs, finalErr
+}
+
+//--------------delete dms------------------//
+type DmsDeleteInputs struct {
+	Inputs []DmsDeleteInput `json:"inputs,omitempty"`
+}
+
+type DmsDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type DmsDeleteOutputs struct {
+	Outputs []DmsDeleteOutput `json:"outputs,omitempty"`
+}
+
+type DmsDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type DmsDeleteAction struct {
+}
+
+func (action *DmsDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DmsDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteDms(input DmsDeleteInput) (output DmsDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createDmsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	_, exist, err := isDmsExist(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	if err = instances.Delete(sc, input.Id).ExtractErr(); err != nil {
+		logrus.Errorf("delete dms[%v] meet err=%v", input.Id, err)
+		return
+	}
+	err = waitDmsDeleteOk(sc, input.Id)
+	return
+}
+
+func (action *DmsDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	dms, _ := inputs.(DmsDeleteInputs)
+	outputs := DmsDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range dms.Inputs {
+		output, err := deleteDms(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dms= %v are delete", dms)
+	return &outputs, finalErr
+}
+
+//--------------query dms------------------//
+type DmsQueryInputs struct {
+	Inputs []DmsQueryInput `json:"inputs,omitempty"`
+}
+
+type DmsQueryInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type DmsQueryOutputs struct {
+	Outputs []DmsQueryOutput `json:"outputs,omitempty"`
+}
+
+type DmsQueryOutput struct {
+	CallBackParameter
+	Result
+	Guid            string `json:"guid,omitempty"`
+	Id              string `json:"id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Status          string `json:"status,omitempty"`
+	Engine          string `json:"engine,omitempty"`
+	EngineVersion   string `json:"engine_version,omitempty"`
+	Specification   string `json:"specification,omitempty"`
+	StorageSpace    string `json:"storage_space,omitempty"`
+	PrivateIp       string `json:"private_ip,omitempty"`
+	Port            string `json:"port,omitempty"`
+	AccessUser      string `json:"access_user,omitempty"`
+	VpcId           string `json:"vpc_id,omitempty"`
+	SubnetId        string `json:"subnet_id,omitempty"`
+	SecurityGroupId string `json:"security_group_id,omitempty"`
+}
+
+type DmsQueryAction struct {
+}
+
+func (action *DmsQueryAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DmsQueryInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func queryDms(input DmsQueryInput) (output DmsQueryOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createDmsServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	dmsInfo, exist, err := isDmsExist(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("dms[%v] is not exist", input.Id)
+		return
+	}
+
+	output.Name = dmsInfo.Name
+	output.Status = dmsInfo.Status
+	output.Engine = dmsInfo.Engine
+	output.EngineVersion = dmsInfo.EngineVersion
+	output.Specification = dmsInfo.Specification
+	output.StorageSpace = strconv.Itoa(dmsInfo.StorageSpace)
+	output.PrivateIp = dmsInfo.ConnectAddress
+	output.Port = fmt.Sprintf("%v", dmsInfo.Port)
+	output.AccessUser = dmsInfo.UserName
+	output.VpcId = dmsInfo.VPCID
+	output.SubnetId = dmsInfo.SubnetID
+	output.SecurityGroupId = dmsInfo.SecurityGroupID
+	return
+}
+
+func (action *DmsQueryAction) Do(inputs interface{}) (interface{}, error) {
+	dms, _ := inputs.(DmsQueryInputs)
+	outputs := DmsQueryOutputs{}
+	var finalErr error
+
+	for _, input := range dms.Inputs {
+		output, err := queryDms(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
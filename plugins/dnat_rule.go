@@ -0,0 +1,372 @@
+package plugins
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DNAT_RULE_PROTOCOL_TCP = "tcp"
+	DNAT_RULE_PROTOCOL_UDP = "udp"
+	DNAT_RULE_PROTOCOL_ANY = "any"
+)
+
+var dnatRuleActions = make(map[string]Action)
+
+func init() {
+	dnatRuleActions["add"] = new(AddDnatRuleAction)
+	dnatRuleActions["delete"] = new(DeleteDnatRuleAction)
+}
+
+type DnatRulePlugin struct {
+}
+
+func (plugin *DnatRulePlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := dnatRuleActions[actionName]
+	if !found {
+		logrus.Errorf("dnatRule plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("dnatRule plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+// the sdk has no dnat rules, so the nat v2.0 api is used directly
+type dnatRule struct {
+	ID                       string `json:"id"`
+	NatGatewayID             string `json:"nat_gateway_id"`
+	PortID                   string `json:"port_id"`
+	PrivateIp                string `json:"private_ip"`
+	InternalServicePort      int    `json:"internal_service_port"`
+	FloatingIpID             string `json:"floating_ip_id"`
+	FloatingIpAddress        string `json:"floating_ip_address"`
+	ExternalServicePort      int    `json:"external_service_port"`
+	InternalServicePortRange string `json:"internal_service_port_range"`
+	ExternalServicePortRange string `json:"external_service_port_range"`
+	Protocol                 string `json:"protocol"`
+	Status                   string `json:"status"`
+}
+
+func getDnatRuleById(sc *golangsdk.ServiceClient, id string) (*dnatRule, bool, error) {
+	var resp struct {
+		DnatRule dnatRule `json:"dnat_rule"`
+	}
+	_, err := sc.Get(sc.ServiceURL("dnat_rules", id), &resp, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok || strings.Contains(err.Error(), "No Dnat Rule exist") {
+			return nil, false, nil
+		}
+		logrus.Errorf("get dnat rule(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	return &resp.DnatRule, true, nil
+}
+
+//------------add dnat rule----------------//
+type AddDnatRuleInputs struct {
+	Inputs []AddDnatRuleInput `json:"inputs,omitempty"`
+}
+
+type AddDnatRuleInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+
+	GatewayId  string `json:"gateway_id,omitempty"`
+	PublicIpId string `json:"public_ip_id,omitempty"`
+	Protocol   string `json:"protocol,omitempty"`
+
+	// single port like 80 or range like 8000-8010, the ranges should have the same length
+	ExternalPort string `json:"external_port,omitempty"`
+	InternalPort string `json:"internal_port,omitempty"`
+
+	// map to the private ip directly, or to the nic of the vm
+	PrivateIp string `json:"private_ip,omitempty"`
+	VmId      string `json:"vm_id,omitempty"`
+	NicIp     string `json:"nic_ip,omitempty"`
+}
+
+type AddDnatRuleOutputs struct {
+	Outputs []AddDnatRuleOutput `json:"outputs,omitempty"`
+}
+
+type AddDnatRuleOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type AddDnatRuleAction struct {
+}
+
+func (action *AddDnatRuleAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs AddDnatRuleInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkAddDnatParam(input AddDnatRuleInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.GatewayId == "" {
+		return fmt.Errorf("gatewayId is empty")
+	}
+	if input.PublicIpId == "" {
+		return fmt.Errorf("publicIpId is empty")
+	}
+	if err := isValidStringValue("protocol", strings.ToLower(input.Protocol), []string{DNAT_RULE_PROTOCOL_TCP, DNAT_RULE_PROTOCOL_UDP, DNAT_RULE_PROTOCOL_ANY}); err != nil {
+		return err
+	}
+	if input.PrivateIp == "" && input.VmId == "" {
+		return fmt.Errorf("privateIp and vmId are both empty")
+	}
+	if input.PrivateIp != "" && net.ParseIP(input.PrivateIp) == nil {
+		return fmt.Errorf("privateIp(%v) is invalid", input.PrivateIp)
+	}
+	return nil
+}
+
+// all ports should use protocol any instead of ALL, and port 0 is not allowed
+func getDnatRulePortMinAndMax(port string) (int, int, error) {
+	if strings.EqualFold(strings.TrimSpace(port), "ALL") {
+		return 0, 0, fmt.Errorf("port(%v) is unsupported, use protocol any to map all ports", port)
+	}
+	portMin, portMax, err := getPortMinAndMax(port)
+	if err != nil {
+		return 0, 0, err
+	}
+	if portMin < 1 {
+		return 0, 0, fmt.Errorf("port(%v) is invalid", port)
+	}
+	return portMin, portMax, nil
+}
+
+// the ports of protocol any are all mapped, so they should not be set
+func getDnatRulePortOpts(protocol string, externalPort string, internalPort string) (map[string]interface{}, error) {
+	opts := map[string]interface{}{}
+	if protocol == DNAT_RULE_PROTOCOL_ANY {
+		if externalPort != "" || internalPort != "" {
+			return nil, fmt.Errorf("ports should be empty when protocol is any")
+		}
+		opts["external_service_port"] = 0
+		opts["internal_service_port"] = 0
+		return opts, nil
+	}
+
+	if externalPort == "" || internalPort == "" {
+		return nil, fmt.Errorf("externalPort and internalPort should not be empty")
+	}
+	externalMin, externalMax, err := getDnatRulePortMinAndMax(externalPort)
+	if err != nil {
+		return nil, err
+	}
+	internalMin, internalMax, err := getDnatRulePortMinAndMax(internalPort)
+	if err != nil {
+		return nil, err
+	}
+	if externalMax-externalMin != internalMax-internalMin {
+		return nil, fmt.Errorf("externalPort(%v) and internalPort(%v) have different length", externalPort, internalPort)
+	}
+	if externalMin == externalMax {
+		opts["external_service_port"] = externalMin
+		opts["internal_service_port"] = internalMin
+		return opts, nil
+	}
+	opts["external_service_port_range"] = fmt.Sprintf("%d-%d", externalMin, externalMax)
+	opts["internal_service_port_range"] = fmt.Sprintf("%d-%d", internalMin, internalMax)
+	return opts, nil
+}
+
+func addDnatRule(input AddDnatRuleInput) (output AddDnatRuleOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkAddDnatParam(input); err != nil {
+		return
+	}
+	protocol := strings.ToLower(input.Protocol)
+	body, err := getDnatRulePortOpts(protocol, input.ExternalPort, input.InternalPort)
+	if err != nil {
+		return
+	}
+
+	sc, err := createNatServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	if input.Id != "" {
+		exist := false
+		_, exist, err = getDnatRuleById(sc, input.Id)
+		if err == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	exist, err := isNatGatewayExist(sc, input.GatewayId)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("natgateway(%v) is not exist", input.GatewayId)
+		return
+	}
+
+	body["nat_gateway_id"] = input.GatewayId
+	body["floating_ip_id"] = input.PublicIpId
+	body["protocol"] = protocol
+	if input.PrivateIp != "" {
+		body["private_ip"] = input.PrivateIp
+	} else {
+		portId := ""
+		if portId, err = getVmPortId(input.CloudProviderParam, input.VmId, input.NicIp); err != nil {
+			return
+		}
+		body["port_id"] = portId
+	}
+
+	var resp struct {
+		DnatRule dnatRule `json:"dnat_rule"`
+	}
+	_, err = sc.Post(sc.ServiceURL("dnat_rules"), map[string]interface{}{"dnat_rule": body}, &resp, &golangsdk.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		logrus.Errorf("create dnat rule failed,err=%v", err)
+		return
+	}
+
+	output.Id = resp.DnatRule.ID
+	return
+}
+
+func (action *AddDnatRuleAction) Do(inputs interface{}) (interface{}, error) {
+	rules, _ := inputs.(AddDnatRuleInputs)
+	outputs := AddDnatRuleOutputs{}
+	var finalErr error
+
+	for _, input := range rules.Inputs {
+		output, err := addDnatRule(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dnat rule = %v are created", rules)
+	return &outputs, finalErr
+}
+
+//------------delete dnat rule----------------//
+type DeleteDnatRuleInputs struct {
+	Inputs []DeleteDnatRuleInput `json:"inputs,omitempty"`
+}
+
+type DeleteDnatRuleInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type DeleteDnatRuleOutputs struct {
+	Outputs []DeleteDnatRuleOutput `json:"outputs,omitempty"`
+}
+
+type DeleteDnatRuleOutput struct {
+	CallBackParameter
+	Result
+	Id   string `json:"id,omitempty"`
+	Guid string `json:"guid,omitempty"`
+}
+
+type DeleteDnatRuleAction struct {
+}
+
+func (action *DeleteDnatRuleAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs DeleteDnatRuleInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteDnatRule(input DeleteDnatRuleInput) (output DeleteDnatRuleOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("empty id")
+		return
+	}
+
+	sc, err := createNatServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	rule, exist, err := getDnatRuleById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	// the rule is deleted under its nat gateway
+	_, err = sc.Delete(sc.ServiceURL("nat_gateways", rule.NatGatewayID, "dnat_rules", input.Id), &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	if err != nil {
+		logrus.Errorf("delete dnat rule failed err=%v", err)
+	}
+
+	return
+}
+
+func (action *DeleteDnatRuleAction) Do(inputs interface{}) (interface{}, error) {
+	rules, _ := inputs.(DeleteDnatRuleInputs)
+	outputs := DeleteDnatRuleOutputs{}
+	var finalErr error
+
+	for _, input := range rules.Inputs {
+		output, err := deleteDnatRule(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all dnat rule = %v are delete", rules)
+	return &outputs, finalErr
+}
@@ -0,0 +1,44 @@
+package plugins
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetDnatRulePortOpts(t *testing.T) {
+	cases := []struct {
+		protocol     string
+		externalPort string
+		internalPort string
+		expected     map[string]interface{}
+		isErr        bool
+	}{
+		{"any", "", "", map[string]interface{}{"external_service_port": 0, "internal_service_port": 0}, false},
+		{"any", "80", "", nil, true},
+		{"any", "", "8080", nil, true},
+		{"tcp", "80", "8080", map[string]interface{}{"external_service_port": 80, "internal_service_port": 8080}, false},
+		{"udp", "8000-8010", "9000-9010", map[string]interface{}{"external_service_port_range": "8000-8010", "internal_service_port_range": "9000-9010"}, false},
+		{"tcp", "8000-8010", "9000-9011", nil, true},
+		{"tcp", "80", "9000-9010", nil, true},
+		{"tcp", "", "8080", nil, true},
+		{"tcp", "80", "", nil, true},
+		{"tcp", "abc", "8080", nil, true},
+		{"tcp", "0", "8080", nil, true},
+		{"tcp", "-5", "8080", nil, true},
+		{"tcp", "80", "0", nil, true},
+		{"tcp", "0-10", "8000-8010", nil, true},
+		{"tcp", "ALL", "ALL", nil, true},
+		{"udp", "all", "1-65535", nil, true},
+	}
+
+	for _, c := range cases {
+		opts, err := getDnatRulePortOpts(c.protocol, c.externalPort, c.internalPort)
+		if (err != nil) != c.isErr {
+			t.Errorf("getDnatRulePortOpts(%v,%v,%v) err=%v, expected err=%v", c.protocol, c.externalPort, c.internalPort, err, c.isErr)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(opts, c.expected) {
+			t.Errorf("getDnatRulePortOpts(%v,%v,%v)=%v, expected %v", c.protocol, c.externalPort, c.internalPort, opts, c.expected)
+		}
+	}
+}
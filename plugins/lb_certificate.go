@@ -0,0 +1,548 @@
+package plugins
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/certificates"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	LB_CERTIFICATE_TYPE_SERVER = "server"
+	LB_CERTIFICATE_TYPE_CA     = "client"
+
+	LB_CERTIFICATE_TIME_FORMAT         = "2006-01-02 15:04:05"
+	LB_CERTIFICATE_DEFAULT_EXPIRE_DAYS = 30
+)
+
+var lbCertificateActions = make(map[string]Action)
+
+func init() {
+	lbCertificateActions["create"] = new(LbCertificateCreateAction)
+	lbCertificateActions["rotate"] = new(LbCertificateRotateAction)
+	lbCertificateActions["delete"] = new(LbCertificateDeleteAction)
+	lbCertificateActions["query"] = new(LbCertificateQueryAction)
+}
+
+type LbCertificatePlugin struct {
+}
+
+func (plugin *LbCertificatePlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := lbCertificateActions[actionName]
+	if !found {
+		logrus.Errorf("lb-certificate plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("lb-certificate plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func getLbCertificateById(sc *gophercloud.ServiceClient, id string) (*certificates.Certificate, bool, error) {
+	certificate, err := certificates.Get(sc, id).Extract()
+	if err != nil {
+		if ue, ok := err.(*gophercloud.UnifiedError); ok {
+			if strings.Contains(ue.Message(), "could not be found") || strings.Contains(ue.Message(), "not found") {
+				return nil, false, nil
+			}
+		}
+		logrus.Errorf("get lb certificate(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	return certificate, true, nil
+}
+
+// parse all the certificates of the pem chain, the first one is returned
+func parseLbCertificate(certificatePem string) (*x509.Certificate, error) {
+	var first *x509.Certificate
+	rest := []byte(strings.TrimSpace(certificatePem))
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, fmt.Errorf("certificate is not a valid pem")
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("pem type(%v) is not CERTIFICATE", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate meet err=%v", err)
+		}
+		if first == nil {
+			first = cert
+		}
+		rest = []byte(strings.TrimSpace(string(rest)))
+	}
+	if first == nil {
+		return nil, fmt.Errorf("certificate is empty")
+	}
+	return first, nil
+}
+
+// server certificate should match the private key, and no expired certificate could be uploaded
+func checkLbCertificate(certificateType string, certificatePem string, privateKey string) (*x509.Certificate, error) {
+	cert, err := parseLbCertificate(certificatePem)
+	if err != nil {
+		return nil, err
+	}
+	if certificateType == LB_CERTIFICATE_TYPE_SERVER {
+		if privateKey == "" {
+			return nil, fmt.Errorf("privateKey is empty")
+		}
+		if _, err = tls.X509KeyPair([]byte(certificatePem), []byte(privateKey)); err != nil {
+			return nil, fmt.Errorf("certificate and privateKey are not matched, err=%v", err)
+		}
+	}
+	if certificateType == LB_CERTIFICATE_TYPE_CA && !cert.IsCA {
+		return nil, fmt.Errorf("certificate(%v) is not a CA certificate", cert.Subject.CommonName)
+	}
+	if time.Now().After(cert.NotAfter) {
+		return nil, fmt.Errorf("certificate(%v) is expired at %v", cert.Subject.CommonName, cert.NotAfter.Format(LB_CERTIFICATE_TIME_FORMAT))
+	}
+	return cert, nil
+}
+
+func getLbCertificateDomain(cert *x509.Certificate) string {
+	if len(cert.DNSNames) > 0 {
+		return strings.Join(cert.DNSNames, ",")
+	}
+	return cert.Subject.CommonName
+}
+
+//--------------create lb certificate------------------//
+type LbCertificateCreateInputs struct {
+	Inputs []LbCertificateCreateInput `json:"inputs,omitempty"`
+}
+
+type LbCertificateCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Type        string `json:"type,omitempty"` //server or client(CA), default server
+	Certificate string `json:"certificate,omitempty"`
+	PrivateKey  string `json:"private_key,omitempty"` //only for server
+	Domain      string `json:"domain,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type LbCertificateCreateOutputs struct {
+	Outputs []LbCertificateCreateOutput `json:"outputs,omitempty"`
+}
+
+type LbCertificateCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	Domain     string `json:"domain,omitempty"`
+	ExpireTime string `json:"expire_time,omitempty"`
+}
+
+type LbCertificateCreateAction struct {
+}
+
+func (action *LbCertificateCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbCertificateCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func createLbCertificate(input LbCertificateCreateInput) (output LbCertificateCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Type == "" {
+		input.Type = LB_CERTIFICATE_TYPE_SERVER
+	}
+	if err = isValidStringValue("type", input.Type, []string{LB_CERTIFICATE_TYPE_SERVER, LB_CERTIFICATE_TYPE_CA}); err != nil {
+		return
+	}
+	cert, err := checkLbCertificate(input.Type, input.Certificate, input.PrivateKey)
+	if err != nil {
+		return
+	}
+	output.Domain = getLbCertificateDomain(cert)
+	output.ExpireTime = cert.NotAfter.Format(LB_CERTIFICATE_TIME_FORMAT)
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	if input.Id != "" {
+		_, exist, getErr := getLbCertificateById(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	opts := certificates.CreateOpts{
+		Name:        input.Name,
+		Description: input.Description,
+		Type:        input.Type,
+		Domain:      input.Domain,
+		Certificate: input.Certificate,
+	}
+	if input.Type == LB_CERTIFICATE_TYPE_SERVER {
+		opts.PrivateKey = input.PrivateKey
+	}
+	resp, err := certificates.Create(sc, opts).Extract()
+	if err != nil {
+		logrus.Errorf("create lb certificate meet err=%v", err)
+		return
+	}
+	output.Id = resp.ID
+	return
+}
+
+func (action *LbCertificateCreateAction) Do(inputs interface{}) (interface{}, error) {
+	certs, _ := inputs.(LbCertificateCreateInputs)
+	outputs := LbCertificateCreateOutputs{}
+	var finalErr error
+
+	for _, input := range certs.Inputs {
+		output, err := createLbCertificate(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------rotate lb certificate------------------//
+type LbCertificateRotateInputs struct {
+	Inputs []LbCertificateRotateInput `json:"inputs,omitempty"`
+}
+
+type LbCertificateRotateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+	PrivateKey  string `json:"private_key,omitempty"`
+	Domain      string `json:"domain,omitempty"`
+}
+
+type LbCertificateRotateOutputs struct {
+	Outputs []LbCertificateRotateOutput `json:"outputs,omitempty"`
+}
+
+type LbCertificateRotateOutput struct {
+	CallBackParameter
+	Result
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	Domain     string `json:"domain,omitempty"`
+	ExpireTime string `json:"expire_time,omitempty"`
+}
+
+type LbCertificateRotateAction struct {
+}
+
+func (action *LbCertificateRotateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbCertificateRotateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// the listeners refer to the certificate id, so rotate the content in place
+func rotateLbCertificate(input LbCertificateRotateInput) (output LbCertificateRotateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	current, exist, err := getLbCertificateById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("lb certificate(%v) is not exist", input.Id)
+		return
+	}
+
+	cert, err := checkLbCertificate(current.Type, input.Certificate, input.PrivateKey)
+	if err != nil {
+		return
+	}
+	output.Domain = getLbCertificateDomain(cert)
+	output.ExpireTime = cert.NotAfter.Format(LB_CERTIFICATE_TIME_FORMAT)
+
+	opts := certificates.UpdateOpts{
+		Certificate: input.Certificate,
+		Domain:      input.Domain,
+	}
+	if current.Type == LB_CERTIFICATE_TYPE_SERVER {
+		opts.PrivateKey = input.PrivateKey
+	}
+	if _, err = certificates.Update(sc, input.Id, opts).Extract(); err != nil {
+		logrus.Errorf("rotate lb certificate(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *LbCertificateRotateAction) Do(inputs interface{}) (interface{}, error) {
+	certs, _ := inputs.(LbCertificateRotateInputs)
+	outputs := LbCertificateRotateOutputs{}
+	var finalErr error
+
+	for _, input := range certs.Inputs {
+		output, err := rotateLbCertificate(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete lb certificate------------------//
+type LbCertificateDeleteInputs struct {
+	Inputs []LbCertificateDeleteInput `json:"inputs,omitempty"`
+}
+
+type LbCertificateDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbCertificateDeleteOutputs struct {
+	Outputs []LbCertificateDeleteOutput `json:"outputs,omitempty"`
+}
+
+type LbCertificateDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbCertificateDeleteAction struct {
+}
+
+func (action *LbCertificateDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbCertificateDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteLbCertificate(input LbCertificateDeleteInput) (output LbCertificateDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	_, exist, err := getLbCertificateById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	if err = certificates.Delete(sc, input.Id).ExtractErr(); err != nil {
+		logrus.Errorf("delete lb certificate(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *LbCertificateDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	certs, _ := inputs.(LbCertificateDeleteInputs)
+	outputs := LbCertificateDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range certs.Inputs {
+		output, err := deleteLbCertificate(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------query lb certificate------------------//
+type LbCertificateQueryInputs struct {
+	Inputs []LbCertificateQueryInput `json:"inputs,omitempty"`
+}
+
+type LbCertificateQueryInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	ExpireDays string `json:"expire_days,omitempty"` //flag the certificate expiring within the days, default 30
+}
+
+type LbCertificateQueryOutputs struct {
+	Outputs []LbCertificateQueryOutput `json:"outputs,omitempty"`
+}
+
+type LbCertificateQueryOutput struct {
+	CallBackParameter
+	Result
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Domain     string `json:"domain,omitempty"`
+	ExpireTime string `json:"expire_time,omitempty"`
+	DaysLeft   string `json:"days_left,omitempty"`
+	IsExpiring string `json:"is_expiring,omitempty"`
+}
+
+type LbCertificateQueryAction struct {
+}
+
+func (action *LbCertificateQueryAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbCertificateQueryInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func queryLbCertificate(input LbCertificateQueryInput) (output LbCertificateQueryOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	var expireDays int64 = LB_CERTIFICATE_DEFAULT_EXPIRE_DAYS
+	if input.ExpireDays != "" {
+		if expireDays, err = isValidInteger(input.ExpireDays, 0, 3650); err != nil {
+			return
+		}
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	certificate, exist, err := getLbCertificateById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("lb certificate(%v) is not exist", input.Id)
+		return
+	}
+
+	cert, err := parseLbCertificate(certificate.Certificate)
+	if err != nil {
+		return
+	}
+	output.Name = certificate.Name
+	output.Type = certificate.Type
+	output.Domain = certificate.Domain
+	if output.Domain == "" {
+		output.Domain = getLbCertificateDomain(cert)
+	}
+	output.ExpireTime = cert.NotAfter.Format(LB_CERTIFICATE_TIME_FORMAT)
+
+	daysLeft := int64(time.Until(cert.NotAfter).Hours() / 24)
+	output.DaysLeft = strconv.FormatInt(daysLeft, 10)
+	output.IsExpiring = strconv.FormatBool(daysLeft <= expireDays)
+	return
+}
+
+func (action *LbCertificateQueryAction) Do(inputs interface{}) (interface{}, error) {
+	certs, _ := inputs.(LbCertificateQueryInputs)
+	outputs := LbCertificateQueryOutputs{}
+	var finalErr error
+
+	for _, input := range certs.Inputs {
+		output, err := queryLbCertificate(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
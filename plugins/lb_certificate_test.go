@@ -0,0 +1,126 @@
+package plugins
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type testLbCertificate struct {
+	certificate string
+	privateKey  string
+}
+
+func newTestLbCertificate(t *testing.T, commonName string, dnsNames []string, isCA bool, notAfter time.Time) testLbCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key meet err=%v", err)
+	}
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              dnsNames,
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate meet err=%v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key meet err=%v", err)
+	}
+	return testLbCertificate{
+		certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		privateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})),
+	}
+}
+
+func TestParseLbCertificate(t *testing.T) {
+	expireAt := time.Now().Add(24 * time.Hour)
+	server := newTestLbCertificate(t, "www.example.com", nil, false, expireAt)
+	ca := newTestLbCertificate(t, "example ca", nil, true, expireAt)
+
+	cases := []struct {
+		name       string
+		pem        string
+		commonName string
+		isErr      bool
+	}{
+		{"single", server.certificate, "www.example.com", false},
+		{"chain returns the first", server.certificate + "\n" + ca.certificate, "www.example.com", false},
+		{"private key is not a certificate", server.privateKey, "", true},
+		{"not a pem", "abc", "", true},
+		{"empty", "  ", "", true},
+	}
+
+	for _, c := range cases {
+		cert, err := parseLbCertificate(c.pem)
+		if (err != nil) != c.isErr {
+			t.Errorf("%v: err=%v, expected err=%v", c.name, err, c.isErr)
+			continue
+		}
+		if err == nil && cert.Subject.CommonName != c.commonName {
+			t.Errorf("%v: commonName=%v, expected %v", c.name, cert.Subject.CommonName, c.commonName)
+		}
+	}
+}
+
+func TestCheckLbCertificate(t *testing.T) {
+	expireAt := time.Now().Add(24 * time.Hour)
+	server := newTestLbCertificate(t, "www.example.com", nil, false, expireAt)
+	other := newTestLbCertificate(t, "api.example.com", nil, false, expireAt)
+	ca := newTestLbCertificate(t, "example ca", nil, true, expireAt)
+	expired := newTestLbCertificate(t, "old.example.com", nil, false, time.Now().Add(-time.Hour))
+
+	cases := []struct {
+		name            string
+		certificateType string
+		certificate     string
+		privateKey      string
+		isErr           bool
+	}{
+		{"server matched", LB_CERTIFICATE_TYPE_SERVER, server.certificate, server.privateKey, false},
+		{"server without key", LB_CERTIFICATE_TYPE_SERVER, server.certificate, "", true},
+		{"server key not matched", LB_CERTIFICATE_TYPE_SERVER, server.certificate, other.privateKey, true},
+		{"ca", LB_CERTIFICATE_TYPE_CA, ca.certificate, "", false},
+		{"ca is not a ca", LB_CERTIFICATE_TYPE_CA, server.certificate, "", true},
+		{"expired", LB_CERTIFICATE_TYPE_SERVER, expired.certificate, expired.privateKey, true},
+	}
+
+	for _, c := range cases {
+		if _, err := checkLbCertificate(c.certificateType, c.certificate, c.privateKey); (err != nil) != c.isErr {
+			t.Errorf("%v: err=%v, expected err=%v", c.name, err, c.isErr)
+		}
+	}
+}
+
+func TestGetLbCertificateDomain(t *testing.T) {
+	expireAt := time.Now().Add(24 * time.Hour)
+	cases := []struct {
+		certificate testLbCertificate
+		expected    string
+	}{
+		{newTestLbCertificate(t, "www.example.com", nil, false, expireAt), "www.example.com"},
+		{newTestLbCertificate(t, "www.example.com", []string{"a.example.com", "b.example.com"}, false, expireAt), "a.example.com,b.example.com"},
+	}
+
+	for _, c := range cases {
+		cert, err := parseLbCertificate(c.certificate.certificate)
+		if err != nil {
+			t.Fatalf("parseLbCertificate meet err=%v", err)
+		}
+		if domain := getLbCertificateDomain(cert); domain != c.expected {
+			t.Errorf("getLbCertificateDomain=%v, expected %v", domain, c.expected)
+		}
+	}
+}
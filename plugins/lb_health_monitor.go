@@ -0,0 +1,574 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/monitors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	LB_MONITOR_DEFAULT_INTERVAL    = 5
+	LB_MONITOR_DEFAULT_TIMEOUT     = 3
+	LB_MONITOR_DEFAULT_MAX_RETRIES = 3
+)
+
+var lbHealthMonitorActions = make(map[string]Action)
+
+func init() {
+	lbHealthMonitorActions["create"] = new(LbHealthMonitorCreateAction)
+	lbHealthMonitorActions["update"] = new(LbHealthMonitorUpdateAction)
+	lbHealthMonitorActions["delete"] = new(LbHealthMonitorDeleteAction)
+	lbHealthMonitorActions["query"] = new(LbHealthMonitorQueryAction)
+}
+
+type LbHealthMonitorPlugin struct {
+}
+
+func (plugin *LbHealthMonitorPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := lbHealthMonitorActions[actionName]
+	if !found {
+		logrus.Errorf("lb-health-monitor plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("lb-health-monitor plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func getLbHealthMonitorById(sc *gophercloud.ServiceClient, id string) (*monitors.Monitor, bool, error) {
+	monitor, err := monitors.Get(sc, id).Extract()
+	if err != nil {
+		if ue, ok := err.(*gophercloud.UnifiedError); ok {
+			if strings.Contains(ue.Message(), "could not be found") {
+				return nil, false, nil
+			}
+		}
+		logrus.Errorf("get lb health monitor(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	return monitor, true, nil
+}
+
+type LbHealthMonitorParam struct {
+	Interval      string `json:"interval,omitempty"`    //seconds between checks, [1,50]
+	Timeout       string `json:"timeout,omitempty"`     //[1,50], not greater than interval
+	MaxRetries    string `json:"max_retries,omitempty"` //[1,10]
+	UrlPath       string `json:"url_path,omitempty"`    //only for HTTP and HTTPS
+	HttpMethod    string `json:"http_method,omitempty"`
+	ExpectedCodes string `json:"expected_codes,omitempty"` //200 or 200,202 or 200-204
+	MonitorPort   string `json:"monitor_port,omitempty"`   //empty means the member port
+	DomainName    string `json:"domain_name,omitempty"`
+}
+
+func checkLbHealthMonitorParam(param LbHealthMonitorParam, monitorType string) error {
+	var interval int64 = LB_MONITOR_DEFAULT_INTERVAL
+	var timeout int64 = LB_MONITOR_DEFAULT_TIMEOUT
+	var err error
+	if param.Interval != "" {
+		if interval, err = isValidInteger(param.Interval, 1, 50); err != nil {
+			return fmt.Errorf("interval(%v) invalid", param.Interval)
+		}
+	}
+	if param.Timeout != "" {
+		if timeout, err = isValidInteger(param.Timeout, 1, 50); err != nil {
+			return fmt.Errorf("timeout(%v) invalid", param.Timeout)
+		}
+	}
+	if timeout > interval {
+		return fmt.Errorf("timeout(%v) is greater than interval(%v)", timeout, interval)
+	}
+	if param.MaxRetries != "" {
+		if _, err = isValidInteger(param.MaxRetries, 1, 10); err != nil {
+			return fmt.Errorf("maxRetries(%v) invalid", param.MaxRetries)
+		}
+	}
+	if param.MonitorPort != "" {
+		if err = isValidPort(param.MonitorPort); err != nil {
+			return err
+		}
+	}
+
+	isHttp := monitorType == monitors.TypeHTTP || monitorType == monitors.TypeHTTPS
+	if !isHttp && (param.UrlPath != "" || param.HttpMethod != "" || param.ExpectedCodes != "" || param.DomainName != "") {
+		return fmt.Errorf("urlPath, httpMethod, expectedCodes and domainName are only supported by HTTP and HTTPS monitor")
+	}
+	if param.UrlPath != "" && !strings.HasPrefix(param.UrlPath, "/") {
+		return fmt.Errorf("urlPath(%v) should start with /", param.UrlPath)
+	}
+	if param.HttpMethod != "" {
+		if err = isValidStringValue("httpMethod", strings.ToUpper(param.HttpMethod), []string{"GET", "HEAD", "POST", "PUT", "DELETE", "TRACE", "OPTIONS", "CONNECT", "PATCH"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//--------------create lb health monitor------------------//
+type LbHealthMonitorCreateInputs struct {
+	Inputs []LbHealthMonitorCreateInput `json:"inputs,omitempty"`
+}
+
+type LbHealthMonitorCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	LbHealthMonitorParam
+	Guid   string `json:"guid,omitempty"`
+	Id     string `json:"id,omitempty"`
+	PoolId string `json:"pool_id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Type   string `json:"type,omitempty"` //TCP, UDP_CONNECT, HTTP or HTTPS
+}
+
+type LbHealthMonitorCreateOutputs struct {
+	Outputs []LbHealthMonitorCreateOutput `json:"outputs,omitempty"`
+}
+
+type LbHealthMonitorCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbHealthMonitorCreateAction struct {
+}
+
+func (action *LbHealthMonitorCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbHealthMonitorCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkCreateLbHealthMonitorParams(input LbHealthMonitorCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.PoolId == "" {
+		return fmt.Errorf("poolId is empty")
+	}
+	if err := isValidStringValue("type", strings.ToUpper(input.Type), []string{
+		monitors.TypeTCP, monitors.TypeUDP, monitors.TypeHTTP, monitors.TypeHTTPS}); err != nil {
+		return err
+	}
+	return checkLbHealthMonitorParam(input.LbHealthMonitorParam, strings.ToUpper(input.Type))
+}
+
+func createLbHealthMonitor(input LbHealthMonitorCreateInput) (output LbHealthMonitorCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkCreateLbHealthMonitorParams(input); err != nil {
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	if input.Id != "" {
+		_, exist, getErr := getLbHealthMonitorById(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	// a pool could only have one health monitor
+	pool, exist, err := getLbPoolById(sc, input.PoolId)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("lb pool(%v) is not exist", input.PoolId)
+		return
+	}
+	if pool.MonitorID != "" {
+		logrus.Infof("lb pool(%v) health monitor(%v) is already exist", input.PoolId, pool.MonitorID)
+		output.Id = pool.MonitorID
+		return
+	}
+
+	opts := monitors.CreateOpts{
+		PoolID:        input.PoolId,
+		Type:          strings.ToUpper(input.Type),
+		Delay:         LB_MONITOR_DEFAULT_INTERVAL,
+		Timeout:       LB_MONITOR_DEFAULT_TIMEOUT,
+		MaxRetries:    LB_MONITOR_DEFAULT_MAX_RETRIES,
+		Name:          input.Name,
+		URLPath:       input.UrlPath,
+		HTTPMethod:    strings.ToUpper(input.HttpMethod),
+		ExpectedCodes: input.ExpectedCodes,
+		DomainName:    input.DomainName,
+	}
+	if input.Interval != "" {
+		opts.Delay, _ = strconv.Atoi(input.Interval)
+	}
+	if input.Timeout != "" {
+		opts.Timeout, _ = strconv.Atoi(input.Timeout)
+	}
+	if input.MaxRetries != "" {
+		opts.MaxRetries, _ = strconv.Atoi(input.MaxRetries)
+	}
+	if input.MonitorPort != "" {
+		opts.MonitorPort, _ = strconv.Atoi(input.MonitorPort)
+	}
+
+	monitor, err := monitors.Create(sc, opts).Extract()
+	if err != nil {
+		logrus.Errorf("create lb health monitor meet err=%v", err)
+		return
+	}
+	output.Id = monitor.ID
+	return
+}
+
+func (action *LbHealthMonitorCreateAction) Do(inputs interface{}) (interface{}, error) {
+	healthMonitors, _ := inputs.(LbHealthMonitorCreateInputs)
+	outputs := LbHealthMonitorCreateOutputs{}
+	var finalErr error
+
+	for _, input := range healthMonitors.Inputs {
+		output, err := createLbHealthMonitor(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------update lb health monitor------------------//
+type LbHealthMonitorUpdateInputs struct {
+	Inputs []LbHealthMonitorUpdateInput `json:"inputs,omitempty"`
+}
+
+type LbHealthMonitorUpdateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	LbHealthMonitorParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type LbHealthMonitorUpdateOutputs struct {
+	Outputs []LbHealthMonitorUpdateOutput `json:"outputs,omitempty"`
+}
+
+type LbHealthMonitorUpdateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbHealthMonitorUpdateAction struct {
+}
+
+func (action *LbHealthMonitorUpdateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbHealthMonitorUpdateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func updateLbHealthMonitor(input LbHealthMonitorUpdateInput) (output LbHealthMonitorUpdateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	monitor, exist, err := getLbHealthMonitorById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("lb health monitor(%v) is not exist", input.Id)
+		return
+	}
+
+	// the unchanged interval or timeout should be checked with the new one
+	param := input.LbHealthMonitorParam
+	if param.Interval == "" {
+		param.Interval = strconv.Itoa(monitor.Delay)
+	}
+	if param.Timeout == "" {
+		param.Timeout = strconv.Itoa(monitor.Timeout)
+	}
+	if err = checkLbHealthMonitorParam(param, monitor.Type); err != nil {
+		return
+	}
+
+	opts := monitors.UpdateOpts{
+		Name:          input.Name,
+		URLPath:       input.UrlPath,
+		HTTPMethod:    strings.ToUpper(input.HttpMethod),
+		ExpectedCodes: input.ExpectedCodes,
+		DomainName:    monitor.DomainName,
+		MonitorPort:   monitor.MonitorPort,
+	}
+	opts.Delay, _ = strconv.Atoi(param.Interval)
+	opts.Timeout, _ = strconv.Atoi(param.Timeout)
+	if input.MaxRetries != "" {
+		opts.MaxRetries, _ = strconv.Atoi(input.MaxRetries)
+	}
+	if input.DomainName != "" {
+		opts.DomainName = &input.DomainName
+	}
+	if input.MonitorPort != "" {
+		monitorPort, _ := strconv.Atoi(input.MonitorPort)
+		opts.MonitorPort = &monitorPort
+	}
+
+	if _, err = monitors.Update(sc, input.Id, opts).Extract(); err != nil {
+		logrus.Errorf("update lb health monitor(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *LbHealthMonitorUpdateAction) Do(inputs interface{}) (interface{}, error) {
+	healthMonitors, _ := inputs.(LbHealthMonitorUpdateInputs)
+	outputs := LbHealthMonitorUpdateOutputs{}
+	var finalErr error
+
+	for _, input := range healthMonitors.Inputs {
+		output, err := updateLbHealthMonitor(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete lb health monitor------------------//
+type LbHealthMonitorDeleteInputs struct {
+	Inputs []LbHealthMonitorDeleteInput `json:"inputs,omitempty"`
+}
+
+type LbHealthMonitorDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbHealthMonitorDeleteOutputs struct {
+	Outputs []LbHealthMonitorDeleteOutput `json:"outputs,omitempty"`
+}
+
+type LbHealthMonitorDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbHealthMonitorDeleteAction struct {
+}
+
+func (action *LbHealthMonitorDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbHealthMonitorDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteLbHealthMonitor(input LbHealthMonitorDeleteInput) (output LbHealthMonitorDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	_, exist, err := getLbHealthMonitorById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	if err = monitors.Delete(sc, input.Id).ExtractErr(); err != nil {
+		logrus.Errorf("delete lb health monitor(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *LbHealthMonitorDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	healthMonitors, _ := inputs.(LbHealthMonitorDeleteInputs)
+	outputs := LbHealthMonitorDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range healthMonitors.Inputs {
+		output, err := deleteLbHealthMonitor(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------query lb member status------------------//
+type LbHealthMonitorQueryInputs struct {
+	Inputs []LbHealthMonitorQueryInput `json:"inputs,omitempty"`
+}
+
+type LbHealthMonitorQueryInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid   string `json:"guid,omitempty"`
+	PoolId string `json:"pool_id,omitempty"`
+}
+
+type LbHealthMonitorQueryOutputs struct {
+	Outputs []LbHealthMonitorQueryOutput `json:"outputs,omitempty"`
+}
+
+type LbHealthMonitorQueryOutput struct {
+	CallBackParameter
+	Result
+	Guid          string `json:"guid,omitempty"`
+	Id            string `json:"id,omitempty"`
+	PoolId        string `json:"pool_id,omitempty"`
+	MemberStatus  string `json:"member_status,omitempty"` //10.0.0.1:80=ONLINE;10.0.0.2:80=OFFLINE
+	OnlineMembers string `json:"online_members,omitempty"`
+	TotalMembers  string `json:"total_members,omitempty"`
+}
+
+type LbHealthMonitorQueryAction struct {
+}
+
+func (action *LbHealthMonitorQueryAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbHealthMonitorQueryInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func queryLbMemberStatus(input LbHealthMonitorQueryInput) (output LbHealthMonitorQueryOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.PoolId = input.PoolId
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.PoolId == "" {
+		err = fmt.Errorf("poolId is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	pool, exist, err := getLbPoolById(sc, input.PoolId)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("lb pool(%v) is not exist", input.PoolId)
+		return
+	}
+	output.Id = pool.MonitorID
+
+	allMembers, err := getAllPoolMembers(sc, input.PoolId)
+	if err != nil {
+		return
+	}
+	status := []string{}
+	online := 0
+	for _, member := range allMembers {
+		status = append(status, fmt.Sprintf("%v:%v=%v", member.Address, member.ProtocolPort, member.OperatingStatus))
+		if member.OperatingStatus == "ONLINE" {
+			online++
+		}
+	}
+	output.MemberStatus = strings.Join(status, ";")
+	output.OnlineMembers = strconv.Itoa(online)
+	output.TotalMembers = strconv.Itoa(len(allMembers))
+	return
+}
+
+func (action *LbHealthMonitorQueryAction) Do(inputs interface{}) (interface{}, error) {
+	healthMonitors, _ := inputs.(LbHealthMonitorQueryInputs)
+	outputs := LbHealthMonitorQueryOutputs{}
+	var finalErr error
+
+	for _, input := range healthMonitors.Inputs {
+		output, err := queryLbMemberStatus(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
@@ -0,0 +1,413 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/policies"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	LB_L7POLICY_REDIRECT_TO_POOL     = "REDIRECT_TO_POOL"
+	LB_L7POLICY_REDIRECT_TO_LISTENER = "REDIRECT_TO_LISTENER"
+)
+
+var lbL7PolicyActions = make(map[string]Action)
+
+func init() {
+	lbL7PolicyActions["create"] = new(LbL7PolicyCreateAction)
+	lbL7PolicyActions["delete"] = new(LbL7PolicyDeleteAction)
+	lbL7PolicyActions["query"] = new(LbL7PolicyQueryAction)
+}
+
+type LbL7PolicyPlugin struct {
+}
+
+func (plugin *LbL7PolicyPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := lbL7PolicyActions[actionName]
+	if !found {
+		logrus.Errorf("lb-l7policy plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("lb-l7policy plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+// the sdk policy has no redirect_listener_id
+type lbL7Policy struct {
+	policies.Policies
+	RedirectListenerID string `json:"redirect_listener_id"`
+}
+
+func getLbL7PolicyById(sc *gophercloud.ServiceClient, id string) (*lbL7Policy, bool, error) {
+	var s struct {
+		Policy *lbL7Policy `json:"l7policy"`
+	}
+	err := policies.Get(sc, id).ExtractInto(&s)
+	if err != nil {
+		if ue, ok := err.(*gophercloud.UnifiedError); ok {
+			if strings.Contains(ue.Message(), "could not be found") {
+				return nil, false, nil
+			}
+		}
+		logrus.Errorf("get lb l7policy(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	return s.Policy, true, nil
+}
+
+// the sdk create only supports REDIRECT_TO_POOL, build the body here
+type lbL7PolicyCreateOpts struct {
+	Name               string `json:"name,omitempty"`
+	Description        string `json:"description,omitempty"`
+	ListenerID         string `json:"listener_id" required:"true"`
+	Action             string `json:"action" required:"true"`
+	RedirectPoolID     string `json:"redirect_pool_id,omitempty"`
+	RedirectListenerID string `json:"redirect_listener_id,omitempty"`
+	Position           int    `json:"position,omitempty"`
+}
+
+func (opts lbL7PolicyCreateOpts) ToPolicyCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "l7policy")
+}
+
+//--------------create lb l7policy------------------//
+type LbL7PolicyCreateInputs struct {
+	Inputs []LbL7PolicyCreateInput `json:"inputs,omitempty"`
+}
+
+type LbL7PolicyCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid               string `json:"guid,omitempty"`
+	Id                 string `json:"id,omitempty"`
+	ListenerId         string `json:"listener_id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	Action             string `json:"action,omitempty"` //REDIRECT_TO_POOL or REDIRECT_TO_LISTENER
+	RedirectPoolId     string `json:"redirect_pool_id,omitempty"`
+	RedirectListenerId string `json:"redirect_listener_id,omitempty"`
+	Position           string `json:"position,omitempty"`
+	Description        string `json:"description,omitempty"`
+}
+
+type LbL7PolicyCreateOutputs struct {
+	Outputs []LbL7PolicyCreateOutput `json:"outputs,omitempty"`
+}
+
+type LbL7PolicyCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbL7PolicyCreateAction struct {
+}
+
+func (action *LbL7PolicyCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbL7PolicyCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkCreateLbL7PolicyParams(input LbL7PolicyCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.ListenerId == "" {
+		return fmt.Errorf("listenerId is empty")
+	}
+	if err := isValidStringValue("action", input.Action, []string{LB_L7POLICY_REDIRECT_TO_POOL, LB_L7POLICY_REDIRECT_TO_LISTENER}); err != nil {
+		return err
+	}
+	if input.Action == LB_L7POLICY_REDIRECT_TO_POOL && input.RedirectPoolId == "" {
+		return fmt.Errorf("redirectPoolId is empty")
+	}
+	if input.Action == LB_L7POLICY_REDIRECT_TO_LISTENER && input.RedirectListenerId == "" {
+		return fmt.Errorf("redirectListenerId is empty")
+	}
+	if input.Position != "" {
+		if _, err := isValidInteger(input.Position, 1, 100); err != nil {
+			return fmt.Errorf("position(%v) invalid", input.Position)
+		}
+	}
+	return nil
+}
+
+func createLbL7Policy(input LbL7PolicyCreateInput) (output LbL7PolicyCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkCreateLbL7PolicyParams(input); err != nil {
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	if input.Id != "" {
+		_, exist, getErr := getLbL7PolicyById(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	opts := lbL7PolicyCreateOpts{
+		Name:        "wecubeCreated",
+		Description: input.Description,
+		ListenerID:  input.ListenerId,
+		Action:      input.Action,
+	}
+	if input.Name != "" {
+		opts.Name = input.Name
+	}
+	if input.Action == LB_L7POLICY_REDIRECT_TO_POOL {
+		opts.RedirectPoolID = input.RedirectPoolId
+	} else {
+		opts.RedirectListenerID = input.RedirectListenerId
+	}
+	if input.Position != "" {
+		opts.Position, _ = strconv.Atoi(input.Position)
+	}
+
+	policy, err := policies.Create(sc, opts).Extract()
+	if err != nil {
+		logrus.Errorf("create lb l7policy meet err=%v", err)
+		return
+	}
+	output.Id = policy.ID
+	return
+}
+
+func (action *LbL7PolicyCreateAction) Do(inputs interface{}) (interface{}, error) {
+	l7policies, _ := inputs.(LbL7PolicyCreateInputs)
+	outputs := LbL7PolicyCreateOutputs{}
+	var finalErr error
+
+	for _, input := range l7policies.Inputs {
+		output, err := createLbL7Policy(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete lb l7policy------------------//
+type LbL7PolicyDeleteInputs struct {
+	Inputs []LbL7PolicyDeleteInput `json:"inputs,omitempty"`
+}
+
+type LbL7PolicyDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbL7PolicyDeleteOutputs struct {
+	Outputs []LbL7PolicyDeleteOutput `json:"outputs,omitempty"`
+}
+
+type LbL7PolicyDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbL7PolicyDeleteAction struct {
+}
+
+func (action *LbL7PolicyDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbL7PolicyDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteLbL7Policy(input LbL7PolicyDeleteInput) (output LbL7PolicyDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	policy, exist, err := getLbL7PolicyById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	// rules should be deleted before the policy
+	for _, rule := range policy.Rules {
+		if err = policies.DeleteRule(sc, input.Id, rule.ID).ExtractErr(); err != nil {
+			logrus.Errorf("delete lb l7policy(%v) rule(%v) meet err=%v", input.Id, rule.ID, err)
+			return
+		}
+	}
+	if err = policies.Delete(sc, input.Id).ExtractErr(); err != nil {
+		logrus.Errorf("delete lb l7policy(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *LbL7PolicyDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	l7policies, _ := inputs.(LbL7PolicyDeleteInputs)
+	outputs := LbL7PolicyDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range l7policies.Inputs {
+		output, err := deleteLbL7Policy(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------query lb l7policy------------------//
+type LbL7PolicyQueryInputs struct {
+	Inputs []LbL7PolicyQueryInput `json:"inputs,omitempty"`
+}
+
+type LbL7PolicyQueryInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbL7PolicyQueryOutputs struct {
+	Outputs []LbL7PolicyQueryOutput `json:"outputs,omitempty"`
+}
+
+type LbL7PolicyQueryOutput struct {
+	CallBackParameter
+	Result
+	Guid               string `json:"guid,omitempty"`
+	Id                 string `json:"id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	ListenerId         string `json:"listener_id,omitempty"`
+	Action             string `json:"action,omitempty"`
+	RedirectPoolId     string `json:"redirect_pool_id,omitempty"`
+	RedirectListenerId string `json:"redirect_listener_id,omitempty"`
+	Position           string `json:"position,omitempty"`
+	RuleIds            string `json:"rule_ids,omitempty"`
+	Status             string `json:"status,omitempty"`
+}
+
+type LbL7PolicyQueryAction struct {
+}
+
+func (action *LbL7PolicyQueryAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbL7PolicyQueryInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func queryLbL7Policy(input LbL7PolicyQueryInput) (output LbL7PolicyQueryOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	policy, exist, err := getLbL7PolicyById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("lb l7policy(%v) is not exist", input.Id)
+		return
+	}
+
+	ruleIds := []string{}
+	for _, rule := range policy.Rules {
+		ruleIds = append(ruleIds, rule.ID)
+	}
+	output.Name = policy.Name
+	output.ListenerId = policy.ListenerID
+	output.Action = policy.Action
+	output.RedirectPoolId = policy.RedirectPoolID
+	output.RedirectListenerId = policy.RedirectListenerID
+	output.Position = strconv.Itoa(policy.Position)
+	output.RuleIds = strings.Join(ruleIds, ",")
+	output.Status = policy.ProvisioningStatus
+	return
+}
+
+func (action *LbL7PolicyQueryAction) Do(inputs interface{}) (interface{}, error) {
+	l7policies, _ := inputs.(LbL7PolicyQueryInputs)
+	outputs := LbL7PolicyQueryOutputs{}
+	var finalErr error
+
+	for _, input := range l7policies.Inputs {
+		output, err := queryLbL7Policy(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
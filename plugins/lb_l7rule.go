@@ -0,0 +1,404 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/policies"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	LB_L7RULE_TYPE_HOST_NAME = "HOST_NAME"
+	LB_L7RULE_TYPE_PATH      = "PATH"
+
+	LB_L7RULE_COMPARE_EQUAL_TO    = "EQUAL_TO"
+	LB_L7RULE_COMPARE_REGEX       = "REGEX"
+	LB_L7RULE_COMPARE_STARTS_WITH = "STARTS_WITH"
+)
+
+var lbL7RuleActions = make(map[string]Action)
+
+func init() {
+	lbL7RuleActions["create"] = new(LbL7RuleCreateAction)
+	lbL7RuleActions["delete"] = new(LbL7RuleDeleteAction)
+	lbL7RuleActions["query"] = new(LbL7RuleQueryAction)
+}
+
+type LbL7RulePlugin struct {
+}
+
+func (plugin *LbL7RulePlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := lbL7RuleActions[actionName]
+	if !found {
+		logrus.Errorf("lb-l7rule plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("lb-l7rule plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func getLbL7RuleById(sc *gophercloud.ServiceClient, policyId string, id string) (*policies.PolicyRule, bool, error) {
+	rule, err := policies.GetRule(sc, policyId, id).Extract()
+	if err != nil {
+		if ue, ok := err.(*gophercloud.UnifiedError); ok {
+			if strings.Contains(ue.Message(), "could not be found") {
+				return nil, false, nil
+			}
+		}
+		logrus.Errorf("get lb l7policy(%v) rule(%v) meet err=%v", policyId, id, err)
+		return nil, false, err
+	}
+	return &rule.Rule, true, nil
+}
+
+func getLbL7Rule(sc *gophercloud.ServiceClient, policyId string, ruleType string, compareType string, value string) (*policies.PolicyRule, error) {
+	allPages, err := policies.ListRules(sc, policies.RulesListOpts{}, policyId).AllPages()
+	if err != nil {
+		logrus.Errorf("list lb l7policy(%v) rules meet err=%v", policyId, err)
+		return nil, err
+	}
+	rules, err := policies.ExtractPolicyRules(allPages)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules.Rules {
+		if rule.Type == ruleType && rule.CompareType == compareType && rule.Value == value {
+			return &rule, nil
+		}
+	}
+	return nil, nil
+}
+
+//--------------create lb l7rule------------------//
+type LbL7RuleCreateInputs struct {
+	Inputs []LbL7RuleCreateInput `json:"inputs,omitempty"`
+}
+
+type LbL7RuleCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	PolicyId    string `json:"policy_id,omitempty"`
+	Type        string `json:"type,omitempty"`         //HOST_NAME or PATH
+	CompareType string `json:"compare_type,omitempty"` //HOST_NAME only supports EQUAL_TO, PATH supports EQUAL_TO,REGEX,STARTS_WITH
+	Value       string `json:"value,omitempty"`
+}
+
+type LbL7RuleCreateOutputs struct {
+	Outputs []LbL7RuleCreateOutput `json:"outputs,omitempty"`
+}
+
+type LbL7RuleCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbL7RuleCreateAction struct {
+}
+
+func (action *LbL7RuleCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbL7RuleCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkCreateLbL7RuleParams(input LbL7RuleCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.PolicyId == "" {
+		return fmt.Errorf("policyId is empty")
+	}
+	if err := isValidStringValue("type", input.Type, []string{LB_L7RULE_TYPE_HOST_NAME, LB_L7RULE_TYPE_PATH}); err != nil {
+		return err
+	}
+	if input.Value == "" {
+		return fmt.Errorf("value is empty")
+	}
+	if input.Type == LB_L7RULE_TYPE_HOST_NAME {
+		if err := isValidStringValue("compareType", input.CompareType, []string{LB_L7RULE_COMPARE_EQUAL_TO}); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if err := isValidStringValue("compareType", input.CompareType, []string{
+		LB_L7RULE_COMPARE_EQUAL_TO, LB_L7RULE_COMPARE_REGEX, LB_L7RULE_COMPARE_STARTS_WITH}); err != nil {
+		return err
+	}
+	if input.CompareType != LB_L7RULE_COMPARE_REGEX && !strings.HasPrefix(input.Value, "/") {
+		return fmt.Errorf("path(%v) should start with /", input.Value)
+	}
+	return nil
+}
+
+func createLbL7Rule(input LbL7RuleCreateInput) (output LbL7RuleCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if input.CompareType == "" {
+		input.CompareType = LB_L7RULE_COMPARE_EQUAL_TO
+	}
+	if err = checkCreateLbL7RuleParams(input); err != nil {
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	if input.Id != "" {
+		_, exist, getErr := getLbL7RuleById(sc, input.PolicyId, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	// the same matching condition is unique in the policy
+	rule, err := getLbL7Rule(sc, input.PolicyId, input.Type, input.CompareType, input.Value)
+	if err != nil {
+		return
+	}
+	if rule != nil {
+		logrus.Infof("lb l7policy(%v) rule(%v %v %v) is already exist", input.PolicyId, input.Type, input.CompareType, input.Value)
+		output.Id = rule.ID
+		return
+	}
+
+	opts := policies.CreateRuleOpts{
+		Type:        input.Type,
+		CompareType: input.CompareType,
+		Value:       input.Value,
+	}
+	resp, err := policies.CreateRule(sc, opts, input.PolicyId).Extract()
+	if err != nil {
+		logrus.Errorf("create lb l7policy(%v) rule meet err=%v", input.PolicyId, err)
+		return
+	}
+	output.Id = resp.Rule.ID
+	return
+}
+
+func (action *LbL7RuleCreateAction) Do(inputs interface{}) (interface{}, error) {
+	rules, _ := inputs.(LbL7RuleCreateInputs)
+	outputs := LbL7RuleCreateOutputs{}
+	var finalErr error
+
+	for _, input := range rules.Inputs {
+		output, err := createLbL7Rule(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete lb l7rule------------------//
+type LbL7RuleDeleteInputs struct {
+	Inputs []LbL7RuleDeleteInput `json:"inputs,omitempty"`
+}
+
+type LbL7RuleDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid     string `json:"guid,omitempty"`
+	Id       string `json:"id,omitempty"`
+	PolicyId string `json:"policy_id,omitempty"`
+}
+
+type LbL7RuleDeleteOutputs struct {
+	Outputs []LbL7RuleDeleteOutput `json:"outputs,omitempty"`
+}
+
+type LbL7RuleDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbL7RuleDeleteAction struct {
+}
+
+func (action *LbL7RuleDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbL7RuleDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteLbL7Rule(input LbL7RuleDeleteInput) (output LbL7RuleDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.PolicyId == "" {
+		err = fmt.Errorf("policyId is empty")
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	_, exist, err := getLbL7RuleById(sc, input.PolicyId, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	if err = policies.DeleteRule(sc, input.PolicyId, input.Id).ExtractErr(); err != nil {
+		logrus.Errorf("delete lb l7policy(%v) rule(%v) meet err=%v", input.PolicyId, input.Id, err)
+	}
+	return
+}
+
+func (action *LbL7RuleDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	rules, _ := inputs.(LbL7RuleDeleteInputs)
+	outputs := LbL7RuleDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range rules.Inputs {
+		output, err := deleteLbL7Rule(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------query lb l7rule------------------//
+type LbL7RuleQueryInputs struct {
+	Inputs []LbL7RuleQueryInput `json:"inputs,omitempty"`
+}
+
+type LbL7RuleQueryInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid     string `json:"guid,omitempty"`
+	Id       string `json:"id,omitempty"`
+	PolicyId string `json:"policy_id,omitempty"`
+}
+
+type LbL7RuleQueryOutputs struct {
+	Outputs []LbL7RuleQueryOutput `json:"outputs,omitempty"`
+}
+
+type LbL7RuleQueryOutput struct {
+	CallBackParameter
+	Result
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	PolicyId    string `json:"policy_id,omitempty"`
+	Type        string `json:"type,omitempty"`
+	CompareType string `json:"compare_type,omitempty"`
+	Value       string `json:"value,omitempty"`
+}
+
+type LbL7RuleQueryAction struct {
+}
+
+func (action *LbL7RuleQueryAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbL7RuleQueryInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func queryLbL7Rule(input LbL7RuleQueryInput) (output LbL7RuleQueryOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.PolicyId = input.PolicyId
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.PolicyId == "" {
+		err = fmt.Errorf("policyId is empty")
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	rule, exist, err := getLbL7RuleById(sc, input.PolicyId, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("lb l7policy(%v) rule(%v) is not exist", input.PolicyId, input.Id)
+		return
+	}
+	output.Type = rule.Type
+	output.CompareType = rule.CompareType
+	output.Value = rule.Value
+	return
+}
+
+func (action *LbL7RuleQueryAction) Do(inputs interface{}) (interface{}, error) {
+	rules, _ := inputs.(LbL7RuleQueryInputs)
+	outputs := LbL7RuleQueryOutputs{}
+	var finalErr error
+
+	for _, input := range rules.Inputs {
+		output, err := queryLbL7Rule(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
@@ -0,0 +1,512 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/listeners"
+	"github.com/sirupsen/logrus"
+)
+
+var lbListenerActions = make(map[string]Action)
+
+func init() {
+	lbListenerActions["create"] = new(LbListenerCreateAction)
+	lbListenerActions["update"] = new(LbListenerUpdateAction)
+	lbListenerActions["delete"] = new(LbListenerDeleteAction)
+}
+
+type LbListenerPlugin struct {
+}
+
+func (plugin *LbListenerPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := lbListenerActions[actionName]
+	if !found {
+		logrus.Errorf("lb-listener plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("lb-listener plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func getLbListenerById(sc *gophercloud.ServiceClient, id string) (*listeners.Listener, bool, error) {
+	listener, err := listeners.Get(sc, id).Extract()
+	if err != nil {
+		if ue, ok := err.(*gophercloud.UnifiedError); ok {
+			if strings.Contains(ue.Message(), "could not be found") {
+				return nil, false, nil
+			}
+		}
+		logrus.Errorf("get lb listener(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	return listener, true, nil
+}
+
+// timeouts are huawei extensions of the listener, in seconds
+type LbListenerTimeoutParam struct {
+	KeepaliveTimeout string `json:"keepalive_timeout,omitempty"` //idle timeout
+	ClientTimeout    string `json:"client_timeout,omitempty"`    //only for HTTP
+	MemberTimeout    string `json:"member_timeout,omitempty"`    //only for HTTP
+}
+
+func checkLbListenerTimeoutParam(param LbListenerTimeoutParam, protocol string) error {
+	isHttp := strings.EqualFold(protocol, string(listeners.ProtocolHTTP)) || strings.EqualFold(protocol, string(listeners.ProtocolTerminatedHTTPS))
+	if param.KeepaliveTimeout != "" {
+		var min int64 = 10
+		if isHttp {
+			min = 0
+		}
+		if _, err := isValidInteger(param.KeepaliveTimeout, min, 4000); err != nil {
+			return fmt.Errorf("keepaliveTimeout(%v) invalid", param.KeepaliveTimeout)
+		}
+	}
+	if !isHttp && (param.ClientTimeout != "" || param.MemberTimeout != "") {
+		return fmt.Errorf("clientTimeout and memberTimeout are only supported by HTTP listener")
+	}
+	if param.ClientTimeout != "" {
+		if _, err := isValidInteger(param.ClientTimeout, 1, 300); err != nil {
+			return fmt.Errorf("clientTimeout(%v) invalid", param.ClientTimeout)
+		}
+	}
+	if param.MemberTimeout != "" {
+		if _, err := isValidInteger(param.MemberTimeout, 1, 300); err != nil {
+			return fmt.Errorf("memberTimeout(%v) invalid", param.MemberTimeout)
+		}
+	}
+	return nil
+}
+
+func addLbListenerTimeouts(body map[string]interface{}, param LbListenerTimeoutParam) {
+	listener := body["listener"].(map[string]interface{})
+	timeouts := map[string]string{
+		"keepalive_timeout": param.KeepaliveTimeout,
+		"client_timeout":    param.ClientTimeout,
+		"member_timeout":    param.MemberTimeout,
+	}
+	for key, value := range timeouts {
+		if value != "" {
+			listener[key], _ = strconv.Atoi(value)
+		}
+	}
+}
+
+// certificates of the TERMINATED_HTTPS listener, ca certificate enables mutual tls
+type LbListenerTlsParam struct {
+	DefaultCertificateId string `json:"default_certificate_id,omitempty"`
+	SniCertificateIds    string `json:"sni_certificate_ids,omitempty"`
+	CaCertificateId      string `json:"ca_certificate_id,omitempty"`
+}
+
+func isLbListenerHttps(protocol string) bool {
+	return strings.EqualFold(protocol, "HTTPS") || strings.EqualFold(protocol, string(listeners.ProtocolTerminatedHTTPS))
+}
+
+// HTTPS is the alias of TERMINATED_HTTPS
+func getLbListenerProtocol(protocol string) string {
+	if isLbListenerHttps(protocol) {
+		return string(listeners.ProtocolTerminatedHTTPS)
+	}
+	return strings.ToUpper(protocol)
+}
+
+func checkLbListenerTlsParam(param LbListenerTlsParam, protocol string, isCreate bool) error {
+	if !isLbListenerHttps(protocol) {
+		if param.DefaultCertificateId != "" || param.SniCertificateIds != "" || param.CaCertificateId != "" {
+			return fmt.Errorf("certificates are only supported by TERMINATED_HTTPS listener")
+		}
+		return nil
+	}
+	if isCreate && param.DefaultCertificateId == "" {
+		return fmt.Errorf("defaultCertificateId is empty")
+	}
+	return nil
+}
+
+func getLbListenerSniCertificateIds(param LbListenerTlsParam) ([]string, error) {
+	return GetArrayFromString(param.SniCertificateIds, ARRAY_SIZE_REAL, 0)
+}
+
+type lbListenerCreateOpts struct {
+	listeners.CreateOpts
+	timeouts LbListenerTimeoutParam
+}
+
+func (opts lbListenerCreateOpts) ToListenerCreateMap() (map[string]interface{}, error) {
+	body, err := opts.CreateOpts.ToListenerCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	addLbListenerTimeouts(body, opts.timeouts)
+	return body, nil
+}
+
+//--------------create lb listener------------------//
+type LbListenerCreateInputs struct {
+	Inputs []LbListenerCreateInput `json:"inputs,omitempty"`
+}
+
+type LbListenerCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	LbListenerTimeoutParam
+	LbListenerTlsParam
+	Guid          string `json:"guid,omitempty"`
+	Id            string `json:"id,omitempty"`
+	LbId          string `json:"lb_id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Protocol      string `json:"protocol,omitempty"` //TCP,UDP,HTTP,TERMINATED_HTTPS(or HTTPS)
+	Port          string `json:"port,omitempty"`
+	DefaultPoolId string `json:"default_pool_id,omitempty"`
+}
+
+type LbListenerCreateOutputs struct {
+	Outputs []LbListenerCreateOutput `json:"outputs,omitempty"`
+}
+
+type LbListenerCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbListenerCreateAction struct {
+}
+
+func (action *LbListenerCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbListenerCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkCreateLbListenerParams(input LbListenerCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.LbId == "" {
+		return fmt.Errorf("lbId is empty")
+	}
+	if err := isValidStringValue("protocol", input.Protocol, []string{
+		string(listeners.ProtocolTCP), string(listeners.ProtocolUDP), string(listeners.ProtocolHTTP),
+		string(listeners.ProtocolTerminatedHTTPS)}); err != nil {
+		return err
+	}
+	if err := isValidPort(input.Port); err != nil {
+		return err
+	}
+	if err := checkLbListenerTlsParam(input.LbListenerTlsParam, input.Protocol, true); err != nil {
+		return err
+	}
+	return checkLbListenerTimeoutParam(input.LbListenerTimeoutParam, input.Protocol)
+}
+
+func createLbListenerWithParams(input LbListenerCreateInput) (output LbListenerCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	input.Protocol = getLbListenerProtocol(input.Protocol)
+	if err = checkCreateLbListenerParams(input); err != nil {
+		return
+	}
+	sniCertificateIds, err := getLbListenerSniCertificateIds(input.LbListenerTlsParam)
+	if err != nil {
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	if input.Id != "" {
+		_, exist, getErr := getLbListenerById(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	// protocol and port are unique in the lb
+	listener, err := getLbListener(sc, input.LbId, input.Protocol, input.Port)
+	if err != nil {
+		return
+	}
+	if listener != nil {
+		logrus.Infof("lb(%v) listener(%v:%v) is already exist", input.LbId, input.Protocol, input.Port)
+		output.Id = listener.ID
+		return
+	}
+
+	port, _ := strconv.Atoi(input.Port)
+	opts := lbListenerCreateOpts{
+		CreateOpts: listeners.CreateOpts{
+			Name:           "wecubeCreated",
+			Protocol:       listeners.Protocol(strings.ToUpper(input.Protocol)),
+			ProtocolPort:   port,
+			DefaultPoolID:  input.DefaultPoolId,
+			LoadbalancerID: input.LbId,
+
+			DefaultTlsContainerRef:  input.DefaultCertificateId,
+			SniContainerRefs:        sniCertificateIds,
+			ClientCaTlsContainerRef: input.CaCertificateId,
+		},
+		timeouts: input.LbListenerTimeoutParam,
+	}
+	if input.Name != "" {
+		opts.Name = input.Name
+	}
+
+	listener, err = listeners.Create(sc, opts).Extract()
+	if err != nil {
+		logrus.Errorf("create lb listener meet err=%v", err)
+		return
+	}
+	output.Id = listener.ID
+	return
+}
+
+func (action *LbListenerCreateAction) Do(inputs interface{}) (interface{}, error) {
+	lbListeners, _ := inputs.(LbListenerCreateInputs)
+	outputs := LbListenerCreateOutputs{}
+	var finalErr error
+
+	for _, input := range lbListeners.Inputs {
+		output, err := createLbListenerWithParams(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------update lb listener------------------//
+type LbListenerUpdateInputs struct {
+	Inputs []LbListenerUpdateInput `json:"inputs,omitempty"`
+}
+
+type LbListenerUpdateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	LbListenerTimeoutParam
+	LbListenerTlsParam
+	Guid          string `json:"guid,omitempty"`
+	Id            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	DefaultPoolId string `json:"default_pool_id,omitempty"`
+}
+
+type LbListenerUpdateOutputs struct {
+	Outputs []LbListenerUpdateOutput `json:"outputs,omitempty"`
+}
+
+type LbListenerUpdateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbListenerUpdateAction struct {
+}
+
+func (action *LbListenerUpdateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbListenerUpdateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func updateLbListener(input LbListenerUpdateInput) (output LbListenerUpdateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	listener, exist, err := getLbListenerById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("lb listener(%v) is not exist", input.Id)
+		return
+	}
+	if err = checkLbListenerTimeoutParam(input.LbListenerTimeoutParam, listener.Protocol); err != nil {
+		return
+	}
+	if err = checkLbListenerTlsParam(input.LbListenerTlsParam, listener.Protocol, false); err != nil {
+		return
+	}
+
+	opts := listeners.UpdateOpts{
+		Name: input.Name,
+	}
+	if input.DefaultPoolId != "" {
+		opts.DefaultPoolID = &input.DefaultPoolId
+	}
+	if input.DefaultCertificateId != "" {
+		opts.DefaultTlsContainerRef = &input.DefaultCertificateId
+	}
+	if input.CaCertificateId != "" {
+		opts.ClientCaTlsContainerRef = &input.CaCertificateId
+	}
+	if input.SniCertificateIds != "" {
+		sniCertificateIds, sniErr := getLbListenerSniCertificateIds(input.LbListenerTlsParam)
+		if sniErr != nil {
+			err = sniErr
+			return
+		}
+		opts.SniContainerRefs = &sniCertificateIds
+	}
+	body, err := opts.ToListenerUpdateMap()
+	if err != nil {
+		return
+	}
+	addLbListenerTimeouts(body, input.LbListenerTimeoutParam)
+
+	// the sdk update only accepts UpdateOpts without timeouts, so put the body directly
+	_, err = sc.Put(sc.ServiceURL("lbaas", "listeners", input.Id), body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		logrus.Errorf("update lb listener(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *LbListenerUpdateAction) Do(inputs interface{}) (interface{}, error) {
+	lbListeners, _ := inputs.(LbListenerUpdateInputs)
+	outputs := LbListenerUpdateOutputs{}
+	var finalErr error
+
+	for _, input := range lbListeners.Inputs {
+		output, err := updateLbListener(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete lb listener------------------//
+type LbListenerDeleteInputs struct {
+	Inputs []LbListenerDeleteInput `json:"inputs,omitempty"`
+}
+
+type LbListenerDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbListenerDeleteOutputs struct {
+	Outputs []LbListenerDeleteOutput `json:"outputs,omitempty"`
+}
+
+type LbListenerDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbListenerDeleteAction struct {
+}
+
+func (action *LbListenerDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbListenerDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteLbListenerWithParams(input LbListenerDeleteInput) (output LbListenerDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	_, exist, err := getLbListenerById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	// the pools are managed by lb-pool plugin, so only the listener is deleted
+	err = deleteLbListener(input.CloudProviderParam, input.Id)
+	return
+}
+
+func (action *LbListenerDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	lbListeners, _ := inputs.(LbListenerDeleteInputs)
+	outputs := LbListenerDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range lbListeners.Inputs {
+		output, err := deleteLbListenerWithParams(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
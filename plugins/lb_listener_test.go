@@ -0,0 +1,67 @@
+package plugins
+
+import (
+	"testing"
+)
+
+func TestGetLbListenerProtocol(t *testing.T) {
+	cases := []struct {
+		protocol string
+		expected string
+	}{
+		{"https", "TERMINATED_HTTPS"},
+		{"HTTPS", "TERMINATED_HTTPS"},
+		{"terminated_https", "TERMINATED_HTTPS"},
+		{"http", "HTTP"},
+		{"TCP", "TCP"},
+		{"", ""},
+	}
+
+	for _, c := range cases {
+		if protocol := getLbListenerProtocol(c.protocol); protocol != c.expected {
+			t.Errorf("getLbListenerProtocol(%v)=%v, expected %v", c.protocol, protocol, c.expected)
+		}
+	}
+}
+
+func TestCheckLbListenerTimeoutParam(t *testing.T) {
+	cases := []struct {
+		protocol string
+		param    LbListenerTimeoutParam
+		isErr    bool
+	}{
+		{"HTTP", LbListenerTimeoutParam{KeepaliveTimeout: "0", ClientTimeout: "60", MemberTimeout: "60"}, false},
+		{getLbListenerProtocol("HTTPS"), LbListenerTimeoutParam{KeepaliveTimeout: "0", ClientTimeout: "60"}, false},
+		{"TCP", LbListenerTimeoutParam{KeepaliveTimeout: "0"}, true},
+		{"TCP", LbListenerTimeoutParam{KeepaliveTimeout: "10"}, false},
+		{"UDP", LbListenerTimeoutParam{MemberTimeout: "60"}, true},
+		{"HTTP", LbListenerTimeoutParam{ClientTimeout: "301"}, true},
+	}
+
+	for _, c := range cases {
+		if err := checkLbListenerTimeoutParam(c.param, c.protocol); (err != nil) != c.isErr {
+			t.Errorf("checkLbListenerTimeoutParam(%++v, %v) err=%v, expected err=%v", c.param, c.protocol, err, c.isErr)
+		}
+	}
+}
+
+func TestIsValidProtocol(t *testing.T) {
+	cases := []struct {
+		protocol string
+		isErr    bool
+	}{
+		{"tcp", false},
+		{"UDP", false},
+		{"HTTP", false},
+		{"HTTPS", false},
+		{"TERMINATED_HTTPS", false},
+		{"ICMP", true},
+		{"", true},
+	}
+
+	for _, c := range cases {
+		if err := isValidProtocol(c.protocol); (err != nil) != c.isErr {
+			t.Errorf("isValidProtocol(%v) err=%v, expected err=%v", c.protocol, err, c.isErr)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/lbaas_v2/pools"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	LB_SESSION_PERSISTENCE_NONE        = "NONE"
+	LB_SESSION_PERSISTENCE_SOURCE_IP   = "SOURCE_IP"
+	LB_SESSION_PERSISTENCE_HTTP_COOKIE = "HTTP_COOKIE"
+	LB_SESSION_PERSISTENCE_APP_COOKIE  = "APP_COOKIE"
+)
+
+var lbPoolActions = make(map[string]Action)
+
+func init() {
+	lbPoolActions["create"] = new(LbPoolCreateAction)
+	lbPoolActions["update"] = new(LbPoolUpdateAction)
+	lbPoolActions["delete"] = new(LbPoolDeleteAction)
+}
+
+type LbPoolPlugin struct {
+}
+
+func (plugin *LbPoolPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := lbPoolActions[actionName]
+	if !found {
+		logrus.Errorf("lb-pool plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("lb-pool plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func getLbPoolById(sc *gophercloud.ServiceClient, id string) (*pools.Pool, bool, error) {
+	pool, err := pools.Get(sc, id).Extract()
+	if err != nil {
+		if ue, ok := err.(*gophercloud.UnifiedError); ok {
+			if strings.Contains(ue.Message(), "could not be found") {
+				return nil, false, nil
+			}
+		}
+		logrus.Errorf("get lb pool(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	return pool, true, nil
+}
+
+func isValidLbAlgorithm(algorithm string) error {
+	return isValidStringValue("lbAlgorithm", algorithm, []string{
+		string(pools.LBMethodRoundRobin), string(pools.LBMethodLeastConnections), string(pools.LBMethodSourceIp),
+	})
+}
+
+type LbPoolPersistenceParam struct {
+	SessionPersistence string `json:"session_persistence,omitempty"` //NONE, SOURCE_IP, HTTP_COOKIE or APP_COOKIE
+	CookieName         string `json:"cookie_name,omitempty"`         //only for APP_COOKIE
+	PersistenceTimeout string `json:"persistence_timeout,omitempty"` //minutes
+}
+
+func checkLbPoolPersistenceParam(param LbPoolPersistenceParam, protocol string) error {
+	if param.SessionPersistence == "" {
+		return nil
+	}
+	if err := isValidStringValue("sessionPersistence", param.SessionPersistence, []string{LB_SESSION_PERSISTENCE_NONE,
+		LB_SESSION_PERSISTENCE_SOURCE_IP, LB_SESSION_PERSISTENCE_HTTP_COOKIE, LB_SESSION_PERSISTENCE_APP_COOKIE}); err != nil {
+		return err
+	}
+	if param.SessionPersistence != LB_SESSION_PERSISTENCE_SOURCE_IP && param.SessionPersistence != LB_SESSION_PERSISTENCE_NONE &&
+		protocol != "" && !strings.EqualFold(protocol, string(pools.ProtocolHTTP)) {
+		return fmt.Errorf("sessionPersistence(%v) is only supported by HTTP pool", param.SessionPersistence)
+	}
+	if param.SessionPersistence == LB_SESSION_PERSISTENCE_APP_COOKIE && param.CookieName == "" {
+		return fmt.Errorf("cookieName is empty")
+	}
+	if param.PersistenceTimeout != "" {
+		if _, err := isValidInteger(param.PersistenceTimeout, 1, 1440); err != nil {
+			return fmt.Errorf("persistenceTimeout(%v) invalid", param.PersistenceTimeout)
+		}
+	}
+	return nil
+}
+
+// persistence_timeout is not supported by the sdk, NONE means disable the session persistence
+func buildLbPoolPersistence(param LbPoolPersistenceParam) interface{} {
+	if param.SessionPersistence == LB_SESSION_PERSISTENCE_NONE {
+		return nil
+	}
+	persistence := map[string]interface{}{
+		"type": param.SessionPersistence,
+	}
+	if param.SessionPersistence == LB_SESSION_PERSISTENCE_APP_COOKIE {
+		persistence["cookie_name"] = param.CookieName
+	}
+	if param.PersistenceTimeout != "" && param.SessionPersistence != LB_SESSION_PERSISTENCE_APP_COOKIE {
+		persistence["persistence_timeout"], _ = strconv.Atoi(param.PersistenceTimeout)
+	}
+	return persistence
+}
+
+type lbPoolCreateOpts struct {
+	pools.CreateOpts
+	persistence LbPoolPersistenceParam
+}
+
+func (opts lbPoolCreateOpts) ToPoolCreateMap() (map[string]interface{}, error) {
+	body, err := opts.CreateOpts.ToPoolCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.persistence.SessionPersistence != "" && opts.persistence.SessionPersistence != LB_SESSION_PERSISTENCE_NONE {
+		body["pool"].(map[string]interface{})["session_persistence"] = buildLbPoolPersistence(opts.persistence)
+	}
+	return body, nil
+}
+
+type lbPoolUpdateOpts struct {
+	pools.UpdateOpts
+	persistence LbPoolPersistenceParam
+}
+
+func (opts lbPoolUpdateOpts) ToPoolUpdateMap() (map[string]interface{}, error) {
+	body, err := opts.UpdateOpts.ToPoolUpdateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.persistence.SessionPersistence != "" {
+		body["pool"].(map[string]interface{})["session_persistence"] = buildLbPoolPersistence(opts.persistence)
+	}
+	return body, nil
+}
+
+//--------------create lb pool------------------//
+type LbPoolCreateInputs struct {
+	Inputs []LbPoolCreateInput `json:"inputs,omitempty"`
+}
+
+type LbPoolCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	LbPoolPersistenceParam
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	LbId        string `json:"lb_id,omitempty"`
+	ListenerId  string `json:"listener_id,omitempty"` //the pool will be the default pool of the listener
+	Name        string `json:"name,omitempty"`
+	Protocol    string `json:"protocol,omitempty"`
+	LbAlgorithm string `json:"lb_algorithm,omitempty"` //default ROUND_ROBIN
+}
+
+type LbPoolCreateOutputs struct {
+	Outputs []LbPoolCreateOutput `json:"outputs,omitempty"`
+}
+
+type LbPoolCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbPoolCreateAction struct {
+}
+
+func (action *LbPoolCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbPoolCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkCreateLbPoolParams(input LbPoolCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.LbId == "" && input.ListenerId == "" {
+		return fmt.Errorf("lbId and listenerId are both empty")
+	}
+	if err := isValidStringValue("protocol", strings.ToUpper(input.Protocol), []string{
+		string(pools.ProtocolTCP), string(pools.ProtocolUDP), string(pools.ProtocolHTTP)}); err != nil {
+		return err
+	}
+	if input.LbAlgorithm != "" {
+		if err := isValidLbAlgorithm(input.LbAlgorithm); err != nil {
+			return err
+		}
+	}
+	return checkLbPoolPersistenceParam(input.LbPoolPersistenceParam, input.Protocol)
+}
+
+func createLbPoolWithParams(input LbPoolCreateInput) (output LbPoolCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkCreateLbPoolParams(input); err != nil {
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	if input.Id != "" {
+		_, exist, getErr := getLbPoolById(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	opts := lbPoolCreateOpts{
+		CreateOpts: pools.CreateOpts{
+			Name:     "wecube_created",
+			LBMethod: pools.LBMethodRoundRobin,
+			Protocol: pools.Protocol(strings.ToUpper(input.Protocol)),
+		},
+		persistence: input.LbPoolPersistenceParam,
+	}
+	if input.Name != "" {
+		opts.Name = input.Name
+	}
+	if input.LbAlgorithm != "" {
+		opts.LBMethod = pools.LBMethod(input.LbAlgorithm)
+	}
+	if input.ListenerId != "" {
+		opts.ListenerID = input.ListenerId
+	} else {
+		opts.LoadbalancerID = input.LbId
+	}
+
+	pool, err := pools.Create(sc, opts).Extract()
+	if err != nil {
+		logrus.Errorf("create lb pool meet err=%v", err)
+		return
+	}
+	output.Id = pool.ID
+	return
+}
+
+func (action *LbPoolCreateAction) Do(inputs interface{}) (interface{}, error) {
+	lbPools, _ := inputs.(LbPoolCreateInputs)
+	outputs := LbPoolCreateOutputs{}
+	var finalErr error
+
+	for _, input := range lbPools.Inputs {
+		output, err := createLbPoolWithParams(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------update lb pool------------------//
+type LbPoolUpdateInputs struct {
+	Inputs []LbPoolUpdateInput `json:"inputs,omitempty"`
+}
+
+type LbPoolUpdateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	LbPoolPersistenceParam
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	LbAlgorithm string `json:"lb_algorithm,omitempty"`
+}
+
+type LbPoolUpdateOutputs struct {
+	Outputs []LbPoolUpdateOutput `json:"outputs,omitempty"`
+}
+
+type LbPoolUpdateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbPoolUpdateAction struct {
+}
+
+func (action *LbPoolUpdateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbPoolUpdateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func updateLbPool(input LbPoolUpdateInput) (output LbPoolUpdateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	if input.LbAlgorithm != "" {
+		if err = isValidLbAlgorithm(input.LbAlgorithm); err != nil {
+			return
+		}
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	pool, exist, err := getLbPoolById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("lb pool(%v) is not exist", input.Id)
+		return
+	}
+	if err = checkLbPoolPersistenceParam(input.LbPoolPersistenceParam, pool.Protocol); err != nil {
+		return
+	}
+
+	opts := lbPoolUpdateOpts{
+		UpdateOpts: pools.UpdateOpts{
+			Name:     input.Name,
+			LBMethod: pools.LBMethod(input.LbAlgorithm),
+		},
+		persistence: input.LbPoolPersistenceParam,
+	}
+	if _, err = pools.Update(sc, input.Id, opts).Extract(); err != nil {
+		logrus.Errorf("update lb pool(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *LbPoolUpdateAction) Do(inputs interface{}) (interface{}, error) {
+	lbPools, _ := inputs.(LbPoolUpdateInputs)
+	outputs := LbPoolUpdateOutputs{}
+	var finalErr error
+
+	for _, input := range lbPools.Inputs {
+		output, err := updateLbPool(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete lb pool------------------//
+type LbPoolDeleteInputs struct {
+	Inputs []LbPoolDeleteInput `json:"inputs,omitempty"`
+}
+
+type LbPoolDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbPoolDeleteOutputs struct {
+	Outputs []LbPoolDeleteOutput `json:"outputs,omitempty"`
+}
+
+type LbPoolDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type LbPoolDeleteAction struct {
+}
+
+func (action *LbPoolDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs LbPoolDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteLbPool(input LbPoolDeleteInput) (output LbPoolDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createLbServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	_, exist, err := getLbPoolById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	err = deleteLbPools(input.CloudProviderParam, input.Id)
+	return
+}
+
+func (action *LbPoolDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	lbPools, _ := inputs.(LbPoolDeleteInputs)
+	outputs := LbPoolDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range lbPools.Inputs {
+		output, err := deleteLbPool(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
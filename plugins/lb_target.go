@@ -56,6 +56,8 @@ type LbHostInput struct {
 	HostIds        string `json:"host_ids"`
 	HostPorts      string `json:"host_ports"`
 	DeleteListener string `json:"delete_listener,omitempty"`
+	PoolId         string `json:"pool_id,omitempty"`      //attach hosts to the pool instead of the listener's default pool
+	HostWeights    string `json:"host_weights,omitempty"` //[0-100], empty means the default weight
 }
 
 type LbHostOutputs struct {
@@ -95,21 +97,22 @@ func isValidProtocol(protocol string) error {
 		return errors.New("protocol is empty")
 	}
 
-	if !strings.EqualFold(protocol, "TCP") && !strings.EqualFold(protocol, "UDP") {
-		return fmt.Errorf("protocol(%s) is invalid", protocol)
-	}
-	return nil
+	return isValidStringValue("protocol", getLbListenerProtocol(protocol), []string{
+		string(listeners.ProtocolTCP), string(listeners.ProtocolUDP), string(listeners.ProtocolHTTP),
+		string(listeners.ProtocolTerminatedHTTPS)})
 }
 
 func checkLbHostInputParam(input LbHostInput) error {
 	if input.HostIds == "" {
 		return errors.New("empty host id")
 	}
-	if err := isValidPort(input.Port); err != nil {
-		return fmt.Errorf("port(%v) is invalid", input.Port)
-	}
-	if err := isValidProtocol(input.Protocol); err != nil {
-		return fmt.Errorf("protocol(%v) is invalid", input.Protocol)
+	if input.PoolId == "" {
+		if err := isValidPort(input.Port); err != nil {
+			return fmt.Errorf("port(%v) is invalid", input.Port)
+		}
+		if err := isValidProtocol(input.Protocol); err != nil {
+			return fmt.Errorf("protocol(%v) is invalid", input.Protocol)
+		}
 	}
 	if input.LbId == "" {
 		return errors.New("empty LbId")
@@ -197,6 +200,10 @@ func ensureLbListenerAndPoolCreated(input LbHostInput) (string, string, error) {
 	}
 
 	if listener == nil {
+		// the https listener needs certificates, which are not inputs here
+		if isLbListenerHttps(input.Protocol) {
+			return "", "", fmt.Errorf("lb(%v) listener(%v:%v) is not exist, create it by lb-listener first", input.LbId, input.Protocol, input.Port)
+		}
 		pool, err := createLbPool(sc, input.LbId, input.Protocol, input.ListenerName)
 		if err != nil {
 			return "", "", err
@@ -215,7 +222,39 @@ func ensureLbListenerAndPoolCreated(input LbHostInput) (string, string, error) {
 	return listener.ID, *listener.DefaultPoolID, nil
 }
 
-func ensureHostAddToLbPool(params CloudProviderParam, hostIds []string, hostPorts []string, poolId string) error {
+func getLbHostWeights(input LbHostInput, size int) ([]string, error) {
+	if input.HostWeights == "" {
+		return nil, nil
+	}
+	weights, err := GetArrayFromString(input.HostWeights, ARRAY_SIZE_AS_EXPECTED, size)
+	if err != nil {
+		return nil, err
+	}
+	for _, weight := range weights {
+		if _, err = isValidInteger(weight, 0, 100); err != nil {
+			return nil, fmt.Errorf("hostWeight(%v) invalid", weight)
+		}
+	}
+	return weights, nil
+}
+
+func updateLbMemberWeight(sc *gophercloud.ServiceClient, poolId string, member pools.Member, weight string) error {
+	weightInt, _ := strconv.Atoi(weight)
+	if member.Weight == weightInt {
+		return nil
+	}
+	opts := pools.UpdateMemberOpts{
+		Weight: &weightInt,
+	}
+	if _, err := pools.UpdateMember(sc, poolId, member.ID, opts).Extract(); err != nil {
+		logrus.Errorf("update lb pool(%v) member(%v) weight meet err=%v", poolId, member.ID, err)
+		return err
+	}
+	return nil
+}
+
+// weights could be nil, which means the default weight
+func ensureHostAddToLbPool(params CloudProviderParam, hostIds []string, hostPorts []string, weights []string, poolId string) error {
 	subnets := []subnets.Subnet{}
 	sc, err := createLbServiceClient(params)
 	if err != nil {
@@ -243,7 +282,18 @@ func ensureHostAddToLbPool(params CloudProviderParam, hostIds []string, hostPort
 		address, _ := getIpFromVmInfo(vm)
 		key := fmt.Sprintf("%v%v", address, hostPorts[i])
 		//check if already exist
-		if _, err = getMemberIdByIpAndPort(allMembers, address, hostPorts[i]); err == nil {
+		if memberId, err := getMemberIdByIpAndPort(allMembers, address, hostPorts[i]); err == nil {
+			if weights == nil {
+				continue
+			}
+			for _, member := range allMembers {
+				if member.ID != memberId {
+					continue
+				}
+				if err = updateLbMemberWeight(sc, poolId, member, weights[i]); err != nil {
+					return err
+				}
+			}
 			continue
 		}
 		if _, ok := addedHost[key]; ok {
@@ -262,6 +312,10 @@ func ensureHostAddToLbPool(params CloudProviderParam, hostIds []string, hostPort
 			SubnetID:     subnetId,
 			Name:         "wecube_created",
 		}
+		if weights != nil {
+			weight, _ := strconv.Atoi(weights[i])
+			opts.Weight = &weight
+		}
 
 		if _, err = pools.CreateMember(sc, poolId, opts).Extract(); err != nil {
 			return err
@@ -284,26 +338,36 @@ func addHostToLb(input LbHostInput) (output LbHostOutput, err error) {
 		}
 	}()
 
+	input.Protocol = getLbListenerProtocol(input.Protocol)
 	if err = checkLbHostInputParam(input); err != nil {
 		return
 	}
 
-	listenerId, poolId, err := ensureLbListenerAndPoolCreated(input)
+	hostIds, err := GetArrayFromString(input.HostIds, ARRAY_SIZE_REAL, 0)
 	if err != nil {
 		return
 	}
-	output.ListenerId = listenerId
 
-	hostIds, err := GetArrayFromString(input.HostIds, ARRAY_SIZE_REAL, 0)
+	hostPorts, err := GetArrayFromString(input.HostPorts, ARRAY_SIZE_AS_EXPECTED, len(hostIds))
 	if err != nil {
 		return
 	}
-
-	hostPorts, err := GetArrayFromString(input.HostPorts, ARRAY_SIZE_AS_EXPECTED, len(hostIds))
+	weights, err := getLbHostWeights(input, len(hostIds))
 	if err != nil {
 		return
 	}
-	err = ensureHostAddToLbPool(input.CloudProviderParam, hostIds, hostPorts, poolId)
+
+	poolId := input.PoolId
+	if poolId == "" {
+		listenerId, defaultPoolId, ensureErr := ensureLbListenerAndPoolCreated(input)
+		if ensureErr != nil {
+			err = ensureErr
+			return
+		}
+		output.ListenerId = listenerId
+		poolId = defaultPoolId
+	}
+	err = ensureHostAddToLbPool(input.CloudProviderParam, hostIds, hostPorts, weights, poolId)
 	return
 }
 
@@ -411,10 +475,26 @@ func delHostFromLb(input LbHostInput) (output LbHostOutput, err error) {
 		}
 	}()
 
+	input.Protocol = getLbListenerProtocol(input.Protocol)
 	if err = checkLbHostInputParam(input); err != nil {
 		return
 	}
 
+	if input.PoolId != "" {
+		hostIds, arrayErr := GetArrayFromString(input.HostIds, ARRAY_SIZE_REAL, 0)
+		if arrayErr != nil {
+			err = arrayErr
+			return
+		}
+		hostPorts, arrayErr := GetArrayFromString(input.HostPorts, ARRAY_SIZE_AS_EXPECTED, len(hostIds))
+		if arrayErr != nil {
+			err = arrayErr
+			return
+		}
+		err = ensureDeleteHostFromPool(input.CloudProviderParam, hostIds, hostPorts, input.PoolId)
+		return
+	}
+
 	if input.ListenerId == "" {
 		err = errors.New("empty listener id")
 		return
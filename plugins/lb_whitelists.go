@@ -5,29 +5,10 @@ import (
 	"strings"
 
 	"github.com/huaweicloud/golangsdk"
-	"github.com/huaweicloud/golangsdk/openstack"
 	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/lbaas_v2/whitelists"
 	"github.com/sirupsen/logrus"
 )
 
-func createLbGolangSdkServiceClient(params CloudProviderParam) (*golangsdk.ServiceClient, error) {
-	client, err := createGolangSdkProviderClient(params)
-	if err != nil {
-		logrus.Errorf("get golangsdk provider client failed, error=%v", err)
-		return nil, err
-	}
-
-	cloudMap, _ := GetMapFromString(params.CloudParams)
-	sc, err := openstack.NewNetworkV2(client, golangsdk.EndpointOpts{
-		Region: cloudMap[CLOUD_PARAM_REGION],
-	})
-	if err != nil {
-		logrus.Errorf("createNatServiceClient meet err=%v", err)
-		return nil, err
-	}
-	return sc, err
-}
-
 var whitelistActions = make(map[string]Action)
 
 func init() {
@@ -128,8 +109,11 @@ func createWhitelist(input *WhitelistCreateInput) (output WhitelistCreateOutput,
 	if err != nil {
 		return
 	}
+	if err = checkLbWhitelistPolicy(list); err != nil {
+		return
+	}
 
-	sc, err := createLbGolangSdkServiceClient(input.CloudProviderParam)
+	sc, err := createNetworkV2ServiceClient(input.CloudProviderParam)
 	if err != nil {
 		return
 	}
@@ -245,8 +229,11 @@ func addWhitelist(input *WhitelistAddInput) (output WhitelistAddOutput, err erro
 	if err != nil {
 		return
 	}
+	if err = checkLbWhitelistPolicy(inputList); err != nil {
+		return
+	}
 
-	sc, err := createLbGolangSdkServiceClient(input.CloudProviderParam)
+	sc, err := createNetworkV2ServiceClient(input.CloudProviderParam)
 	if err != nil {
 		return
 	}
@@ -374,7 +361,7 @@ func removeWhitelist(input *WhitelistRemoveInput) (output WhitelistRemoveOutput,
 		}
 	}
 
-	sc, err := createLbGolangSdkServiceClient(input.CloudProviderParam)
+	sc, err := createNetworkV2ServiceClient(input.CloudProviderParam)
 	if err != nil {
 		return
 	}
@@ -490,7 +477,7 @@ func deleteWhitelist(input *WhitelistDeleteInput) (output WhitelistDeleteOutput,
 		return
 	}
 
-	sc, err := createLbGolangSdkServiceClient(input.CloudProviderParam)
+	sc, err := createNetworkV2ServiceClient(input.CloudProviderParam)
 	if err != nil {
 		return
 	}
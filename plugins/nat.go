@@ -28,11 +28,38 @@ func createNatServiceClient(params CloudProviderParam) (*golangsdk.ServiceClient
 	return sc, err
 }
 
+const (
+	NAT_GATEWAY_SPEC_SMALL  = "small"
+	NAT_GATEWAY_SPEC_MEDIUM = "medium"
+	NAT_GATEWAY_SPEC_LARGE  = "large"
+	NAT_GATEWAY_SPEC_XLARGE = "xlarge"
+)
+
+// the api spec is 1 to 4 for small to xlarge
+var natGatewaySpecs = map[string]string{
+	NAT_GATEWAY_SPEC_SMALL:  "1",
+	NAT_GATEWAY_SPEC_MEDIUM: "2",
+	NAT_GATEWAY_SPEC_LARGE:  "3",
+	NAT_GATEWAY_SPEC_XLARGE: "4",
+}
+
+func getNatGatewaySpec(spec string) (string, error) {
+	if spec == "" {
+		return natGatewaySpecs[NAT_GATEWAY_SPEC_SMALL], nil
+	}
+	apiSpec, ok := natGatewaySpecs[strings.ToLower(spec)]
+	if !ok {
+		return "", fmt.Errorf("spec(%v) is invalid, should be one of small/medium/large/xlarge", spec)
+	}
+	return apiSpec, nil
+}
+
 var natActions = make(map[string]Action)
 
 func init() {
 	natActions["create"] = new(NatCreateAction)
 	natActions["delete"] = new(NatDeleteAction)
+	natActions["resize"] = new(NatResizeAction)
 }
 
 type NatPlugin struct {
@@ -60,6 +87,7 @@ type NatCreateInput struct {
 
 	VpcId    string `json:"vpc_id,omitempty"`
 	SubnetId string `json:"subnet_id,omitempty"`
+	Spec     string `json:"spec,omitempty"`
 }
 
 type NatCreateOutputs struct {
@@ -101,6 +129,10 @@ func checkNatGatewayCreateParam(input NatCreateInput) error {
 	if input.SubnetId == "" {
 		return fmt.Errorf("subnetId is empty")
 	}
+
+	if _, err := getNatGatewaySpec(input.Spec); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -148,10 +180,11 @@ func createNatGateway(input NatCreateInput) (output NatCreateOutput, err error)
 
 	//create natgateway
 	cloudMap, _ := GetMapFromString(input.CloudProviderParam.CloudParams)
+	spec, _ := getNatGatewaySpec(input.Spec)
 	opts := natgateways.CreateOpts{
 		TenantID:          cloudMap[CLOUD_PARAM_PROJECT_ID],
 		Name:              input.Name,
-		Spec:              "1",
+		Spec:              spec,
 		RouterID:          input.VpcId,
 		InternalNetworkID: input.SubnetId,
 	}
@@ -270,3 +303,105 @@ func (action *NatDeleteAction) Do(inputs interface{}) (interface{}, error) {
 	logrus.Infof("all natGateway = %v are created", gateways)
 	return &outputs, finalErr
 }
+
+//---------resize nat gateway------------//
+type NatResizeInputs struct {
+	Inputs []NatResizeInput `json:"inputs,omitempty"`
+}
+
+type NatResizeInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+	Spec string `json:"spec,omitempty"`
+}
+
+type NatResizeOutputs struct {
+	Outputs []NatResizeOutput `json:"outputs,omitempty"`
+}
+
+type NatResizeOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type NatResizeAction struct {
+}
+
+func (action *NatResizeAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs NatResizeInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func resizeNatGateway(input NatResizeInput) (output NatResizeOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("empty id")
+		return
+	}
+	if input.Spec == "" {
+		err = fmt.Errorf("spec is empty")
+		return
+	}
+	spec, err := getNatGatewaySpec(input.Spec)
+	if err != nil {
+		return
+	}
+
+	sc, err := createNatServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	gateway, err := natgateways.Get(sc, input.Id).Extract()
+	if err != nil {
+		logrus.Errorf("get natgateway(%v) meet err=%v", input.Id, err)
+		return
+	}
+	if gateway.Spec == spec {
+		logrus.Infof("natgateway(%v) spec is already %v", input.Id, input.Spec)
+		return
+	}
+
+	if _, err = natgateways.Update(sc, input.Id, natgateways.UpdateOpts{Spec: spec}).Extract(); err != nil {
+		logrus.Errorf("natgateway(%v) resize failed,err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *NatResizeAction) Do(inputs interface{}) (interface{}, error) {
+	gateways, _ := inputs.(NatResizeInputs)
+	outputs := NatResizeOutputs{}
+	var finalErr error
+
+	for _, input := range gateways.Inputs {
+		output, err := resizeNatGateway(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all natGateway = %v are resized", gateways)
+	return &outputs, finalErr
+}
@@ -0,0 +1,995 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/huaweicloud/golangsdk"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/firewall_groups"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/policies"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/extensions/fwaas_v2/rules"
+	"github.com/huaweicloud/golangsdk/openstack/networking/v2/ports"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	NETWORK_ACL_ACTION_ALLOW = "allow"
+	NETWORK_ACL_ACTION_DENY  = "deny"
+
+	NETWORK_ACL_INBOUND  = "inbound"
+	NETWORK_ACL_OUTBOUND = "outbound"
+
+	NETWORK_ACL_ROUTER_INTERFACE_OWNER = "network:router_interface_distributed"
+)
+
+var networkAclActions = make(map[string]Action)
+
+func init() {
+	networkAclActions["create"] = new(NetworkAclCreateAction)
+	networkAclActions["delete"] = new(NetworkAclDeleteAction)
+	networkAclActions["associate"] = new(NetworkAclAssociateAction)
+	networkAclActions["disassociate"] = new(NetworkAclDisassociateAction)
+	networkAclActions["sync"] = new(NetworkAclSyncAction)
+}
+
+type NetworkAclPlugin struct {
+}
+
+func (plugin *NetworkAclPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := networkAclActions[actionName]
+	if !found {
+		logrus.Errorf("network-acl plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("network-acl plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+// the sdk firewall group has no ports, which are the router interfaces of the associated subnets
+type networkAclGroup struct {
+	firewall_groups.FirewallGroup
+	Ports []string `json:"ports"`
+}
+
+func getNetworkAclById(sc *golangsdk.ServiceClient, id string) (*networkAclGroup, bool, error) {
+	group := networkAclGroup{}
+	err := firewall_groups.Get(sc, id).ExtractInto(&group)
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return nil, false, nil
+		}
+		logrus.Errorf("get network acl(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	return &group, true, nil
+}
+
+func waitNetworkAclDeleteOk(sc *golangsdk.ServiceClient, id string) error {
+	count := 0
+	for {
+		time.Sleep(time.Second * 2)
+		_, exist, err := getNetworkAclById(sc, id)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return nil
+		}
+
+		count++
+		if count > 30 {
+			break
+		}
+	}
+	return fmt.Errorf("after %vs, delete network acl(%v) time out", count*2, id)
+}
+
+type networkAclGroupCreateOpts struct {
+	firewall_groups.CreateOpts
+	ports []string
+}
+
+func (opts networkAclGroupCreateOpts) ToFirewallGroupCreateMap() (map[string]interface{}, error) {
+	body, err := opts.CreateOpts.ToFirewallGroupCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.ports) > 0 {
+		body["firewall_group"].(map[string]interface{})["ports"] = opts.ports
+	}
+	return body, nil
+}
+
+// the sdk update omits the empty list, so put the body directly
+func updateNetworkAclPorts(sc *golangsdk.ServiceClient, id string, portIds []string) error {
+	body := map[string]interface{}{
+		"firewall_group": map[string]interface{}{
+			"ports": portIds,
+		},
+	}
+	_, err := sc.Put(sc.ServiceURL("fwaas", "firewall_groups", id), body, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("update network acl(%v) ports(%v) meet err=%v", id, portIds, err)
+	}
+	return err
+}
+
+func updateNetworkAclPolicyRules(sc *golangsdk.ServiceClient, policyId string, ruleIds []string) error {
+	body := map[string]interface{}{
+		"firewall_policy": map[string]interface{}{
+			"firewall_rules": ruleIds,
+		},
+	}
+	_, err := sc.Put(sc.ServiceURL("fwaas", "firewall_policies", policyId), body, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("update network acl policy(%v) rules(%v) meet err=%v", policyId, ruleIds, err)
+	}
+	return err
+}
+
+// the router interface port of the subnet is bound to the firewall group
+func getNetworkAclSubnetPortIds(sc *golangsdk.ServiceClient, subnetIds []string) ([]string, error) {
+	portIds := []string{}
+	for _, subnetId := range subnetIds {
+		allPages, err := ports.List(sc, ports.ListOpts{
+			NetworkID:   subnetId,
+			DeviceOwner: NETWORK_ACL_ROUTER_INTERFACE_OWNER,
+		}).AllPages()
+		if err != nil {
+			logrus.Errorf("list subnet(%v) ports meet err=%v", subnetId, err)
+			return portIds, err
+		}
+		subnetPorts, err := ports.ExtractPorts(allPages)
+		if err != nil {
+			return portIds, err
+		}
+		if len(subnetPorts) == 0 {
+			return portIds, fmt.Errorf("subnet(%v) has no router interface", subnetId)
+		}
+		portIds = append(portIds, subnetPorts[0].ID)
+	}
+	return portIds, nil
+}
+
+// the normalized rule, empty address or port means any
+type networkAclRule struct {
+	Action          string
+	Protocol        string
+	SourceIp        string
+	SourcePort      string
+	DestinationIp   string
+	DestinationPort string
+}
+
+func (rule networkAclRule) String() string {
+	fields := []string{rule.Action, rule.Protocol, rule.SourceIp, rule.SourcePort, rule.DestinationIp, rule.DestinationPort}
+	for i, field := range fields {
+		if field == "" {
+			fields[i] = "any"
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+func newNetworkAclRule(rule rules.Rule) networkAclRule {
+	aclRule := networkAclRule{
+		Action:          rule.Action,
+		Protocol:        strings.ToLower(rule.Protocol),
+		SourceIp:        rule.SourceIPAddress,
+		SourcePort:      rule.SourcePort,
+		DestinationIp:   rule.DestinationIPAddress,
+		DestinationPort: rule.DestinationPort,
+	}
+	if aclRule.Protocol == "" {
+		aclRule.Protocol = string(rules.ProtocolAny)
+	}
+	return aclRule
+}
+
+func getNetworkAclRuleIp(ip string) (string, error) {
+	if ip == "any" || ip == "*" {
+		return "", nil
+	}
+	if err := isValidIpOrCidr(ip); err != nil {
+		return "", err
+	}
+	return ip, nil
+}
+
+// fwaas port range is separated by colon
+func getNetworkAclRulePort(port string) (string, error) {
+	if port == "any" || port == "*" {
+		return "", nil
+	}
+	portMin, portMax, err := getPortMinAndMax(port)
+	if err != nil {
+		return "", err
+	}
+	if portMin == portMax {
+		return fmt.Sprintf("%v", portMin), nil
+	}
+	return fmt.Sprintf("%v:%v", portMin, portMax), nil
+}
+
+// rules are separated by comma and kept in order, each rule is
+// "action protocol [source_ip] [source_port] [destination_ip] [destination_port]",
+// the omitted fields and "any" match everything. e.g. "allow tcp 10.0.0.0/8 any any 22,deny any"
+func parseNetworkAclRules(rawRules string) ([]networkAclRule, error) {
+	aclRules := []networkAclRule{}
+	entries, err := GetArrayFromString(rawRules, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return aclRules, err
+	}
+
+	for _, entry := range entries {
+		fields := strings.Fields(strings.ToLower(entry))
+		if len(fields) < 2 || len(fields) > 6 {
+			return aclRules, fmt.Errorf("rule(%v) is invalid", entry)
+		}
+		for len(fields) < 6 {
+			fields = append(fields, "any")
+		}
+
+		rule := networkAclRule{
+			Action:   fields[0],
+			Protocol: fields[1],
+		}
+		if err = isValidStringValue("action", rule.Action, []string{NETWORK_ACL_ACTION_ALLOW, NETWORK_ACL_ACTION_DENY}); err != nil {
+			return aclRules, err
+		}
+		if err = isValidStringValue("protocol", rule.Protocol, []string{
+			string(rules.ProtocolTCP), string(rules.ProtocolUDP), string(rules.ProtocolICMP), string(rules.ProtocolAny)}); err != nil {
+			return aclRules, err
+		}
+		if rule.SourceIp, err = getNetworkAclRuleIp(fields[2]); err != nil {
+			return aclRules, err
+		}
+		if rule.SourcePort, err = getNetworkAclRulePort(fields[3]); err != nil {
+			return aclRules, err
+		}
+		if rule.DestinationIp, err = getNetworkAclRuleIp(fields[4]); err != nil {
+			return aclRules, err
+		}
+		if rule.DestinationPort, err = getNetworkAclRulePort(fields[5]); err != nil {
+			return aclRules, err
+		}
+		isPortProtocol := rule.Protocol == string(rules.ProtocolTCP) || rule.Protocol == string(rules.ProtocolUDP)
+		if !isPortProtocol && (rule.SourcePort != "" || rule.DestinationPort != "") {
+			return aclRules, fmt.Errorf("rule(%v) port is only supported by tcp and udp", entry)
+		}
+		aclRules = append(aclRules, rule)
+	}
+	return aclRules, nil
+}
+
+func createNetworkAclRule(sc *golangsdk.ServiceClient, rule networkAclRule) (string, error) {
+	ipVersion := golangsdk.IPv4
+	if strings.Contains(rule.SourceIp, ":") || strings.Contains(rule.DestinationIp, ":") {
+		ipVersion = golangsdk.IPv6
+	}
+	opts := rules.CreateOpts{
+		Protocol:             rules.Protocol(rule.Protocol),
+		Action:               rule.Action,
+		IPVersion:            ipVersion,
+		SourceIPAddress:      rule.SourceIp,
+		SourcePort:           rule.SourcePort,
+		DestinationIPAddress: rule.DestinationIp,
+		DestinationPort:      rule.DestinationPort,
+	}
+	resp, err := rules.Create(sc, opts).Extract()
+	if err != nil {
+		logrus.Errorf("create network acl rule(%v) meet err=%v", rule, err)
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func deleteNetworkAclRules(sc *golangsdk.ServiceClient, ruleIds []string) error {
+	for _, ruleId := range ruleIds {
+		if err := rules.Delete(sc, ruleId).ExtractErr(); err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				continue
+			}
+			logrus.Errorf("delete network acl rule(%v) meet err=%v", ruleId, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// the created rules are deleted if any of them fails
+func createNetworkAclRules(sc *golangsdk.ServiceClient, aclRules []networkAclRule) ([]string, error) {
+	ruleIds := []string{}
+	for _, rule := range aclRules {
+		ruleId, err := createNetworkAclRule(sc, rule)
+		if err != nil {
+			if deleteErr := deleteNetworkAclRules(sc, ruleIds); deleteErr != nil {
+				err = fmt.Errorf("create rule meet err=%v && clean up created rules meet err=%v", err, deleteErr)
+			}
+			return []string{}, err
+		}
+		ruleIds = append(ruleIds, ruleId)
+	}
+	return ruleIds, nil
+}
+
+// delete the policy and its rules
+func deleteNetworkAclPolicy(sc *golangsdk.ServiceClient, policyId string) error {
+	if policyId == "" {
+		return nil
+	}
+	policy, err := policies.Get(sc, policyId).Extract()
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			return nil
+		}
+		logrus.Errorf("get network acl policy(%v) meet err=%v", policyId, err)
+		return err
+	}
+	if err = policies.Delete(sc, policyId).ExtractErr(); err != nil {
+		logrus.Errorf("delete network acl policy(%v) meet err=%v", policyId, err)
+		return err
+	}
+	return deleteNetworkAclRules(sc, policy.Rules)
+}
+
+func createNetworkAclPolicy(sc *golangsdk.ServiceClient, name string, aclRules []networkAclRule) (string, error) {
+	ruleIds, err := createNetworkAclRules(sc, aclRules)
+	if err != nil {
+		return "", err
+	}
+	policy, err := policies.Create(sc, policies.CreateOpts{
+		Name:  name,
+		Rules: ruleIds,
+	}).Extract()
+	if err != nil {
+		logrus.Errorf("create network acl policy(%v) meet err=%v", name, err)
+		if deleteErr := deleteNetworkAclRules(sc, ruleIds); deleteErr != nil {
+			err = fmt.Errorf("create policy meet err=%v && clean up created rules meet err=%v", err, deleteErr)
+		}
+		return "", err
+	}
+	return policy.ID, nil
+}
+
+//--------------create network acl------------------//
+type NetworkAclCreateInputs struct {
+	Inputs []NetworkAclCreateInput `json:"inputs,omitempty"`
+}
+
+type NetworkAclCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid          string `json:"guid,omitempty"`
+	Id            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	InboundRules  string `json:"inbound_rules,omitempty"`
+	OutboundRules string `json:"outbound_rules,omitempty"`
+	SubnetIds     string `json:"subnet_ids,omitempty"`
+}
+
+type NetworkAclCreateOutputs struct {
+	Outputs []NetworkAclCreateOutput `json:"outputs,omitempty"`
+}
+
+type NetworkAclCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid             string `json:"guid,omitempty"`
+	Id               string `json:"id,omitempty"`
+	InboundPolicyId  string `json:"inbound_policy_id,omitempty"`
+	OutboundPolicyId string `json:"outbound_policy_id,omitempty"`
+}
+
+type NetworkAclCreateAction struct {
+}
+
+func (action *NetworkAclCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs NetworkAclCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func createNetworkAcl(input NetworkAclCreateInput) (output NetworkAclCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	inboundRules, err := parseNetworkAclRules(input.InboundRules)
+	if err != nil {
+		return
+	}
+	outboundRules, err := parseNetworkAclRules(input.OutboundRules)
+	if err != nil {
+		return
+	}
+	subnetIds, err := GetArrayFromString(input.SubnetIds, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+
+	sc, err := createNetworkV2ServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	if input.Id != "" {
+		group, exist, getErr := getNetworkAclById(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			output.InboundPolicyId = group.IngressPolicyID
+			output.OutboundPolicyId = group.EgressPolicyID
+			return
+		}
+	}
+	portIds, err := getNetworkAclSubnetPortIds(sc, subnetIds)
+	if err != nil {
+		return
+	}
+
+	name := "wecubeCreated"
+	if input.Name != "" {
+		name = input.Name
+	}
+	defer func() {
+		if err != nil && output.Id == "" {
+			// the deleted policies should not be returned
+			for _, policyId := range []*string{&output.InboundPolicyId, &output.OutboundPolicyId} {
+				if deleteErr := deleteNetworkAclPolicy(sc, *policyId); deleteErr != nil {
+					err = fmt.Errorf("create network acl meet err=%v && clean up policy(%v) meet err=%v", err, *policyId, deleteErr)
+					return
+				}
+				*policyId = ""
+			}
+		}
+	}()
+
+	// a direction without rules has no policy
+	if len(inboundRules) > 0 {
+		if output.InboundPolicyId, err = createNetworkAclPolicy(sc, name+"-"+NETWORK_ACL_INBOUND, inboundRules); err != nil {
+			return
+		}
+	}
+	if len(outboundRules) > 0 {
+		if output.OutboundPolicyId, err = createNetworkAclPolicy(sc, name+"-"+NETWORK_ACL_OUTBOUND, outboundRules); err != nil {
+			return
+		}
+	}
+
+	opts := networkAclGroupCreateOpts{
+		CreateOpts: firewall_groups.CreateOpts{
+			Name:            name,
+			Description:     input.Description,
+			IngressPolicyID: output.InboundPolicyId,
+			EgressPolicyID:  output.OutboundPolicyId,
+		},
+		ports: portIds,
+	}
+	group, err := firewall_groups.Create(sc, opts).Extract()
+	if err != nil {
+		logrus.Errorf("create network acl meet err=%v", err)
+		return
+	}
+	output.Id = group.ID
+	return
+}
+
+func (action *NetworkAclCreateAction) Do(inputs interface{}) (interface{}, error) {
+	acls, _ := inputs.(NetworkAclCreateInputs)
+	outputs := NetworkAclCreateOutputs{}
+	var finalErr error
+
+	for _, input := range acls.Inputs {
+		output, err := createNetworkAcl(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete network acl------------------//
+type NetworkAclDeleteInputs struct {
+	Inputs []NetworkAclDeleteInput `json:"inputs,omitempty"`
+}
+
+type NetworkAclDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type NetworkAclDeleteOutputs struct {
+	Outputs []NetworkAclDeleteOutput `json:"outputs,omitempty"`
+}
+
+type NetworkAclDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type NetworkAclDeleteAction struct {
+}
+
+func (action *NetworkAclDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs NetworkAclDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteNetworkAcl(input NetworkAclDeleteInput) (output NetworkAclDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createNetworkV2ServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	group, exist, err := getNetworkAclById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	// subnets should be disassociated before deleting
+	if len(group.Ports) > 0 {
+		if err = updateNetworkAclPorts(sc, input.Id, []string{}); err != nil {
+			return
+		}
+	}
+	if err = firewall_groups.Delete(sc, input.Id).ExtractErr(); err != nil {
+		logrus.Errorf("delete network acl(%v) meet err=%v", input.Id, err)
+		return
+	}
+	if err = waitNetworkAclDeleteOk(sc, input.Id); err != nil {
+		return
+	}
+
+	if err = deleteNetworkAclPolicy(sc, group.IngressPolicyID); err != nil {
+		return
+	}
+	err = deleteNetworkAclPolicy(sc, group.EgressPolicyID)
+	return
+}
+
+func (action *NetworkAclDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	acls, _ := inputs.(NetworkAclDeleteInputs)
+	outputs := NetworkAclDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range acls.Inputs {
+		output, err := deleteNetworkAcl(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------associate and disassociate subnets------------------//
+type NetworkAclSubnetInputs struct {
+	Inputs []NetworkAclSubnetInput `json:"inputs,omitempty"`
+}
+
+type NetworkAclSubnetInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	SubnetIds string `json:"subnet_ids,omitempty"`
+}
+
+type NetworkAclSubnetOutputs struct {
+	Outputs []NetworkAclSubnetOutput `json:"outputs,omitempty"`
+}
+
+type NetworkAclSubnetOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type NetworkAclAssociateAction struct {
+}
+
+func (action *NetworkAclAssociateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs NetworkAclSubnetInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+type NetworkAclDisassociateAction struct {
+}
+
+func (action *NetworkAclDisassociateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs NetworkAclSubnetInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func updateNetworkAclSubnets(input NetworkAclSubnetInput, isAssociate bool) (output NetworkAclSubnetOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	subnetIds, err := GetArrayFromString(input.SubnetIds, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+	if len(subnetIds) == 0 {
+		err = fmt.Errorf("subnetIds is empty")
+		return
+	}
+
+	sc, err := createNetworkV2ServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	group, exist, err := getNetworkAclById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("network acl(%v) is not exist", input.Id)
+		return
+	}
+	portIds, err := getNetworkAclSubnetPortIds(sc, subnetIds)
+	if err != nil {
+		return
+	}
+
+	newPortIds := []string{}
+	if isAssociate {
+		newPortIds = MergeTwoArraysString(group.Ports, portIds)
+	} else {
+		removePorts := map[string]bool{}
+		for _, portId := range portIds {
+			removePorts[portId] = true
+		}
+		for _, portId := range group.Ports {
+			if !removePorts[portId] {
+				newPortIds = append(newPortIds, portId)
+			}
+		}
+	}
+	if len(newPortIds) == len(group.Ports) {
+		logrus.Infof("network acl(%v) ports are not changed", input.Id)
+		return
+	}
+	err = updateNetworkAclPorts(sc, input.Id, newPortIds)
+	return
+}
+
+func (action *NetworkAclAssociateAction) Do(inputs interface{}) (interface{}, error) {
+	acls, _ := inputs.(NetworkAclSubnetInputs)
+	outputs := NetworkAclSubnetOutputs{}
+	var finalErr error
+
+	for _, input := range acls.Inputs {
+		output, err := updateNetworkAclSubnets(input, true)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+func (action *NetworkAclDisassociateAction) Do(inputs interface{}) (interface{}, error) {
+	acls, _ := inputs.(NetworkAclSubnetInputs)
+	outputs := NetworkAclSubnetOutputs{}
+	var finalErr error
+
+	for _, input := range acls.Inputs {
+		output, err := updateNetworkAclSubnets(input, false)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------sync network acl rules------------------//
+type NetworkAclSyncInputs struct {
+	Inputs []NetworkAclSyncInput `json:"inputs,omitempty"`
+}
+
+type NetworkAclSyncInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid          string `json:"guid,omitempty"`
+	Id            string `json:"id,omitempty"`
+	InboundRules  string `json:"inbound_rules,omitempty"`
+	OutboundRules string `json:"outbound_rules,omitempty"`
+}
+
+type NetworkAclSyncOutputs struct {
+	Outputs []NetworkAclSyncOutput `json:"outputs,omitempty"`
+}
+
+type NetworkAclSyncOutput struct {
+	CallBackParameter
+	Result
+	Guid             string `json:"guid,omitempty"`
+	Id               string `json:"id,omitempty"`
+	InboundPolicyId  string `json:"inbound_policy_id,omitempty"`
+	OutboundPolicyId string `json:"outbound_policy_id,omitempty"`
+	AddedRules       string `json:"added_rules,omitempty"`
+	RemovedRules     string `json:"removed_rules,omitempty"`
+}
+
+type NetworkAclSyncAction struct {
+}
+
+func (action *NetworkAclSyncAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs NetworkAclSyncInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// the changes of one direction, used to roll back or clean up after the sync
+type networkAclPolicySync struct {
+	PolicyId        string
+	IsNewPolicy     bool
+	OriginRuleIds   []string
+	CreatedRuleIds  []string
+	ObsoleteRuleIds []string
+	AddedRules      []string
+	RemovedRules    []string
+}
+
+func (policySync *networkAclPolicySync) rollback(sc *golangsdk.ServiceClient) error {
+	if policySync.IsNewPolicy {
+		return deleteNetworkAclPolicy(sc, policySync.PolicyId)
+	}
+	if policySync.PolicyId != "" {
+		if err := updateNetworkAclPolicyRules(sc, policySync.PolicyId, policySync.OriginRuleIds); err != nil {
+			return err
+		}
+	}
+	return deleteNetworkAclRules(sc, policySync.CreatedRuleIds)
+}
+
+// existing rules are reused in the desired order, the missing ones are created
+func syncNetworkAclPolicy(sc *golangsdk.ServiceClient, policySync *networkAclPolicySync, name string, direction string, desiredRules []networkAclRule) error {
+	currentRules := map[string]networkAclRule{}
+	if policySync.PolicyId != "" {
+		policy, err := policies.Get(sc, policySync.PolicyId).Extract()
+		if err != nil {
+			logrus.Errorf("get network acl policy(%v) meet err=%v", policySync.PolicyId, err)
+			return err
+		}
+		policySync.OriginRuleIds = policy.Rules
+		for _, ruleId := range policy.Rules {
+			rule, err := rules.Get(sc, ruleId).Extract()
+			if err != nil {
+				logrus.Errorf("get network acl rule(%v) meet err=%v", ruleId, err)
+				return err
+			}
+			currentRules[ruleId] = newNetworkAclRule(*rule)
+		}
+	}
+
+	usedRuleIds := map[string]bool{}
+	ruleIds := []string{}
+	for _, desiredRule := range desiredRules {
+		ruleId := ""
+		for _, currentRuleId := range policySync.OriginRuleIds {
+			if !usedRuleIds[currentRuleId] && currentRules[currentRuleId] == desiredRule {
+				ruleId = currentRuleId
+				break
+			}
+		}
+		if ruleId == "" {
+			var err error
+			if ruleId, err = createNetworkAclRule(sc, desiredRule); err != nil {
+				return err
+			}
+			policySync.CreatedRuleIds = append(policySync.CreatedRuleIds, ruleId)
+			policySync.AddedRules = append(policySync.AddedRules, direction+" "+desiredRule.String())
+		}
+		usedRuleIds[ruleId] = true
+		ruleIds = append(ruleIds, ruleId)
+	}
+	for _, ruleId := range policySync.OriginRuleIds {
+		if !usedRuleIds[ruleId] {
+			policySync.ObsoleteRuleIds = append(policySync.ObsoleteRuleIds, ruleId)
+			policySync.RemovedRules = append(policySync.RemovedRules, direction+" "+currentRules[ruleId].String())
+		}
+	}
+
+	if policySync.PolicyId == "" {
+		if len(ruleIds) == 0 {
+			return nil
+		}
+		policy, err := policies.Create(sc, policies.CreateOpts{
+			Name:  name + "-" + direction,
+			Rules: ruleIds,
+		}).Extract()
+		if err != nil {
+			logrus.Errorf("create network acl policy meet err=%v", err)
+			return err
+		}
+		policySync.PolicyId = policy.ID
+		policySync.IsNewPolicy = true
+		return nil
+	}
+	if strings.Join(ruleIds, ",") == strings.Join(policySync.OriginRuleIds, ",") {
+		return nil
+	}
+	return updateNetworkAclPolicyRules(sc, policySync.PolicyId, ruleIds)
+}
+
+func syncNetworkAcl(input NetworkAclSyncInput) (output NetworkAclSyncOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	inboundRules, err := parseNetworkAclRules(input.InboundRules)
+	if err != nil {
+		return
+	}
+	outboundRules, err := parseNetworkAclRules(input.OutboundRules)
+	if err != nil {
+		return
+	}
+
+	sc, err := createNetworkV2ServiceClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	group, exist, err := getNetworkAclById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("network acl(%v) is not exist", input.Id)
+		return
+	}
+
+	inbound := &networkAclPolicySync{PolicyId: group.IngressPolicyID}
+	outbound := &networkAclPolicySync{PolicyId: group.EgressPolicyID}
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, policySync := range []*networkAclPolicySync{inbound, outbound} {
+			if rollbackErr := policySync.rollback(sc); rollbackErr != nil {
+				err = fmt.Errorf("sync network acl meet err=%v && roll back meet err=%v", err, rollbackErr)
+				return
+			}
+		}
+	}()
+
+	if err = syncNetworkAclPolicy(sc, inbound, group.Name, NETWORK_ACL_INBOUND, inboundRules); err != nil {
+		return
+	}
+	if err = syncNetworkAclPolicy(sc, outbound, group.Name, NETWORK_ACL_OUTBOUND, outboundRules); err != nil {
+		return
+	}
+	if inbound.IsNewPolicy || outbound.IsNewPolicy {
+		opts := firewall_groups.UpdateOpts{
+			IngressPolicyID: inbound.PolicyId,
+			EgressPolicyID:  outbound.PolicyId,
+		}
+		if _, err = firewall_groups.Update(sc, input.Id, opts).Extract(); err != nil {
+			logrus.Errorf("update network acl(%v) policies meet err=%v", input.Id, err)
+			return
+		}
+	}
+
+	// the rules removed from the policies are not used any more
+	for _, policySync := range []*networkAclPolicySync{inbound, outbound} {
+		if deleteErr := deleteNetworkAclRules(sc, policySync.ObsoleteRuleIds); deleteErr != nil {
+			logrus.Warnf("clean up network acl(%v) obsolete rules meet err=%v", input.Id, deleteErr)
+		}
+	}
+
+	output.InboundPolicyId = inbound.PolicyId
+	output.OutboundPolicyId = outbound.PolicyId
+	output.AddedRules = strings.Join(append(inbound.AddedRules, outbound.AddedRules...), ",")
+	output.RemovedRules = strings.Join(append(inbound.RemovedRules, outbound.RemovedRules...), ",")
+	return
+}
+
+func (action *NetworkAclSyncAction) Do(inputs interface{}) (interface{}, error) {
+	acls, _ := inputs.(NetworkAclSyncInputs)
+	outputs := NetworkAclSyncOutputs{}
+	var finalErr error
+
+	for _, input := range acls.Inputs {
+		output, err := syncNetworkAcl(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
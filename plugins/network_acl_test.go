@@ -0,0 +1,46 @@
+package plugins
+
+import (
+	"testing"
+)
+
+func TestParseNetworkAclRules(t *testing.T) {
+	cases := []struct {
+		rawRules string
+		expected []string
+		isErr    bool
+	}{
+		{"", []string{}, false},
+		{"allow tcp 10.0.0.0/8 any any 22,deny any", []string{"allow tcp 10.0.0.0/8 any any 22", "deny any any any any any"}, false},
+		{"ALLOW UDP * 1000-2000 192.168.1.1 53", []string{"allow udp any 1000:2000 192.168.1.1 53"}, false},
+		{"allow icmp fd00::/8", []string{"allow icmp fd00::/8 any any any"}, false},
+		{"allow icmp any any any 22", nil, true},
+		{"allow any any 80", nil, true},
+		{"accept tcp", nil, true},
+		{"allow sctp", nil, true},
+		{"allow", nil, true},
+		{"allow tcp any any any 22 more", nil, true},
+		{"allow tcp 10.0.0.300", nil, true},
+		{"allow tcp any 70000", nil, true},
+	}
+
+	for _, c := range cases {
+		aclRules, err := parseNetworkAclRules(c.rawRules)
+		if (err != nil) != c.isErr {
+			t.Errorf("parseNetworkAclRules(%v) err=%v, expected err=%v", c.rawRules, err, c.isErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(aclRules) != len(c.expected) {
+			t.Errorf("parseNetworkAclRules(%v)=%v, expected %v", c.rawRules, aclRules, c.expected)
+			continue
+		}
+		for i, rule := range aclRules {
+			if rule.String() != c.expected[i] {
+				t.Errorf("parseNetworkAclRules(%v)[%v]=%v, expected %v", c.rawRules, i, rule, c.expected[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,742 @@
+package plugins
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/huaweicloud/golangsdk/openstack/obs"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	OBS_SSE_ALGORITHM_KMS = "kms"
+
+	OBS_DELETE_OBJECTS_MAX_NUM = 1000
+)
+
+var obsBucketActions = make(map[string]Action)
+
+func init() {
+	obsBucketActions["create"] = new(ObsBucketCreateAction)
+	obsBucketActions["delete"] = new(ObsBucketDeleteAction)
+	obsBucketActions["query"] = new(ObsBucketQueryAction)
+}
+
+type ObsBucketPlugin struct {
+}
+
+func (plugin *ObsBucketPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := obsBucketActions[actionName]
+	if !found {
+		logrus.Errorf("obs-bucket plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("obs-bucket plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func getObsEndpoint(params CloudProviderParam) string {
+	cloudMap, _ := GetMapFromString(params.CloudParams)
+	return "obs." + cloudMap[CLOUD_PARAM_REGION] + "." + cloudMap[CLOUD_PARAM_CLOUD_DOAMIN_NAME]
+}
+
+func createObsClient(params CloudProviderParam) (*obs.ObsClient, error) {
+	if err := isCloudProviderParamValid(params); err != nil {
+		return nil, err
+	}
+	identifyMap, _ := GetMapFromString(params.IdentityParams)
+
+	client, err := obs.New(identifyMap[IDENTITY_ACCESS_KEY], identifyMap[IDENTITY_SECRET_KEY], "https://"+getObsEndpoint(params))
+	if err != nil {
+		logrus.Errorf("createObsClient meet err=%v", err)
+		return nil, err
+	}
+	return client, nil
+}
+
+func isObsBucketExist(client *obs.ObsClient, bucketName string) (bool, error) {
+	_, err := client.HeadBucket(bucketName)
+	if err != nil {
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		logrus.Errorf("head obs bucket(%v) meet err=%v", bucketName, err)
+		return false, err
+	}
+	return true, nil
+}
+
+type ObsBucketCreateInputs struct {
+	Inputs []ObsBucketCreateInput `json:"inputs,omitempty"`
+}
+
+type ObsBucketCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	StorageClass string `json:"storage_class,omitempty"`
+	Acl          string `json:"acl,omitempty"`
+	Versioning   string `json:"versioning,omitempty"`
+	Policy       string `json:"policy,omitempty"`
+
+	//lifecycle rule
+	LifecyclePrefix                   string `json:"lifecycle_prefix,omitempty"`
+	LifecycleExpirationDays           string `json:"lifecycle_expiration_days,omitempty"`
+	LifecycleTransitionWarmDays       string `json:"lifecycle_transition_warm_days,omitempty"`
+	LifecycleTransitionColdDays       string `json:"lifecycle_transition_cold_days,omitempty"`
+	LifecycleNoncurrentExpirationDays string `json:"lifecycle_noncurrent_expiration_days,omitempty"`
+
+	//cors rule
+	CorsAllowedOrigins string `json:"cors_allowed_origins,omitempty"`
+	CorsAllowedMethods string `json:"cors_allowed_methods,omitempty"`
+	CorsAllowedHeaders string `json:"cors_allowed_headers,omitempty"`
+	CorsExposeHeaders  string `json:"cors_expose_headers,omitempty"`
+	CorsMaxAgeSeconds  string `json:"cors_max_age_seconds,omitempty"`
+
+	//server side encryption
+	SseAlgorithm string `json:"sse_algorithm,omitempty"`
+	SseKmsKeyId  string `json:"sse_kms_key_id,omitempty"`
+}
+
+type ObsBucketCreateOutputs struct {
+	Outputs []ObsBucketCreateOutput `json:"outputs,omitempty"`
+}
+
+type ObsBucketCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	DomainName string `json:"domain_name,omitempty"`
+}
+
+type ObsBucketCreateAction struct {
+}
+
+func (action *ObsBucketCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs ObsBucketCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkObsBucketCreateParams(input ObsBucketCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Name == "" {
+		return fmt.Errorf("name is empty")
+	}
+	if input.StorageClass != "" {
+		if err := isValidStringValue("storage_class", input.StorageClass, []string{string(obs.StorageClassStandard), string(obs.StorageClassWarm), string(obs.StorageClassCold)}); err != nil {
+			return err
+		}
+	}
+	if input.Acl != "" {
+		validAcls := []string{string(obs.AclPrivate), string(obs.AclPublicRead), string(obs.AclPublicReadWrite), string(obs.AclAuthenticatedRead),
+			string(obs.AclBucketOwnerRead), string(obs.AclBucketOwnerFullControl), string(obs.AclLogDeliveryWrite)}
+		if err := isValidStringValue("acl", input.Acl, validAcls); err != nil {
+			return err
+		}
+	}
+	if input.Versioning != "" {
+		if err := isValidStringValue("versioning", input.Versioning, []string{string(obs.VersioningStatusEnabled), string(obs.VersioningStatusSuspended)}); err != nil {
+			return err
+		}
+	}
+
+	days := map[string]string{
+		"lifecycle_expiration_days":            input.LifecycleExpirationDays,
+		"lifecycle_transition_warm_days":       input.LifecycleTransitionWarmDays,
+		"lifecycle_transition_cold_days":       input.LifecycleTransitionColdDays,
+		"lifecycle_noncurrent_expiration_days": input.LifecycleNoncurrentExpirationDays,
+		"cors_max_age_seconds":                 input.CorsMaxAgeSeconds,
+	}
+	for key, value := range days {
+		if value == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s(%v) is not a number", key, value)
+		}
+	}
+
+	if input.CorsAllowedOrigins != "" && input.CorsAllowedMethods == "" {
+		return fmt.Errorf("cors_allowed_methods is empty")
+	}
+	if input.SseAlgorithm != "" && input.SseAlgorithm != OBS_SSE_ALGORITHM_KMS {
+		return fmt.Errorf("sse_algorithm(%v) is invalid,only support %v", input.SseAlgorithm, OBS_SSE_ALGORITHM_KMS)
+	}
+	if input.SseKmsKeyId != "" && input.SseAlgorithm == "" {
+		return fmt.Errorf("sse_algorithm is empty while sse_kms_key_id is set")
+	}
+	return nil
+}
+
+func buildObsLifecycleRule(input ObsBucketCreateInput) (*obs.LifecycleRule, error) {
+	if input.LifecycleExpirationDays == "" && input.LifecycleTransitionWarmDays == "" &&
+		input.LifecycleTransitionColdDays == "" && input.LifecycleNoncurrentExpirationDays == "" {
+		return nil, nil
+	}
+
+	rule := &obs.LifecycleRule{
+		ID:     "rule-" + input.Name,
+		Prefix: input.LifecyclePrefix,
+		Status: obs.RuleStatusEnabled,
+	}
+	if input.LifecycleTransitionWarmDays != "" {
+		days, _ := strconv.Atoi(input.LifecycleTransitionWarmDays)
+		rule.Transitions = append(rule.Transitions, obs.Transition{Days: days, StorageClass: obs.StorageClassWarm})
+	}
+	if input.LifecycleTransitionColdDays != "" {
+		days, _ := strconv.Atoi(input.LifecycleTransitionColdDays)
+		rule.Transitions = append(rule.Transitions, obs.Transition{Days: days, StorageClass: obs.StorageClassCold})
+	}
+	if input.LifecycleExpirationDays != "" {
+		days, _ := strconv.Atoi(input.LifecycleExpirationDays)
+		for _, transition := range rule.Transitions {
+			if transition.Days >= days {
+				return nil, fmt.Errorf("lifecycle_expiration_days(%v) should be larger than transition days(%v)", days, transition.Days)
+			}
+		}
+		rule.Expiration = obs.Expiration{Days: days}
+	}
+	if input.LifecycleNoncurrentExpirationDays != "" {
+		days, _ := strconv.Atoi(input.LifecycleNoncurrentExpirationDays)
+		rule.NoncurrentVersionExpiration = obs.NoncurrentVersionExpiration{NoncurrentDays: days}
+	}
+	return rule, nil
+}
+
+func buildObsCorsRule(input ObsBucketCreateInput) (*obs.CorsRule, error) {
+	if input.CorsAllowedOrigins == "" {
+		return nil, nil
+	}
+
+	origins, err := GetArrayFromString(input.CorsAllowedOrigins, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := GetArrayFromString(input.CorsAllowedMethods, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, method := range methods {
+		if err = isValidStringValue("cors_allowed_methods", method, []string{"GET", "PUT", "HEAD", "POST", "DELETE"}); err != nil {
+			return nil, err
+		}
+	}
+	headers, err := GetArrayFromString(input.CorsAllowedHeaders, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return nil, err
+	}
+	exposeHeaders, err := GetArrayFromString(input.CorsExposeHeaders, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &obs.CorsRule{
+		AllowedOrigin: origins,
+		AllowedMethod: methods,
+		AllowedHeader: headers,
+		ExposeHeader:  exposeHeaders,
+	}
+	if input.CorsMaxAgeSeconds != "" {
+		rule.MaxAgeSeconds, _ = strconv.Atoi(input.CorsMaxAgeSeconds)
+	}
+	return rule, nil
+}
+
+type obsBucketEncryptionConfiguration struct {
+	XMLName        xml.Name `xml:"ServerSideEncryptionConfiguration"`
+	SSEAlgorithm   string   `xml:"Rule>ApplyServerSideEncryptionByDefault>SSEAlgorithm"`
+	KMSMasterKeyID string   `xml:"Rule>ApplyServerSideEncryptionByDefault>KMSMasterKeyID,omitempty"`
+}
+
+// the vendored obs sdk has no bucket encryption api, so sign the request ourselves
+func setObsBucketEncryption(params CloudProviderParam, bucketName string, algorithm string, kmsKeyId string) error {
+	body, err := xml.Marshal(obsBucketEncryptionConfiguration{
+		SSEAlgorithm:   algorithm,
+		KMSMasterKeyID: kmsKeyId,
+	})
+	if err != nil {
+		return err
+	}
+
+	md5Sum := md5.Sum(body)
+	contentMd5 := base64.StdEncoding.EncodeToString(md5Sum[:])
+	contentType := "application/xml"
+	date := time.Now().UTC().Format(http.TimeFormat)
+
+	identifyMap, _ := GetMapFromString(params.IdentityParams)
+	stringToSign := strings.Join([]string{http.MethodPut, contentMd5, contentType, date, "/" + bucketName + "/?encryption"}, "\n")
+	mac := hmac.New(sha1.New, []byte(identifyMap[IDENTITY_SECRET_KEY]))
+	mac.Write([]byte(stringToSign))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	url := "https://" + bucketName + "." + getObsEndpoint(params) + "/?encryption"
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-MD5", contentMd5)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Date", date)
+	req.Header.Set("Authorization", "OBS "+identifyMap[IDENTITY_ACCESS_KEY]+":"+signature)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		logrus.Errorf("set obs bucket(%v) encryption meet err=%v", bucketName, err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		logrus.Errorf("set obs bucket(%v) encryption failed,status=%v,body=%v", bucketName, resp.StatusCode, string(respBody))
+		return fmt.Errorf("set obs bucket(%v) encryption failed,status=%v,body=%v", bucketName, resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+func setObsBucketConfiguration(client *obs.ObsClient, input ObsBucketCreateInput) error {
+	if input.Versioning != "" {
+		versioningInput := &obs.SetBucketVersioningInput{Bucket: input.Name}
+		versioningInput.Status = obs.VersioningStatusType(input.Versioning)
+		if _, err := client.SetBucketVersioning(versioningInput); err != nil {
+			logrus.Errorf("set obs bucket versioning meet err=%v", err)
+			return err
+		}
+	}
+
+	lifecycleRule, err := buildObsLifecycleRule(input)
+	if err != nil {
+		return err
+	}
+	if lifecycleRule != nil {
+		lifecycleInput := &obs.SetBucketLifecycleConfigurationInput{Bucket: input.Name}
+		lifecycleInput.LifecycleRules = []obs.LifecycleRule{*lifecycleRule}
+		if _, err = client.SetBucketLifecycleConfiguration(lifecycleInput); err != nil {
+			logrus.Errorf("set obs bucket lifecycle meet err=%v", err)
+			return err
+		}
+	}
+
+	corsRule, err := buildObsCorsRule(input)
+	if err != nil {
+		return err
+	}
+	if corsRule != nil {
+		corsInput := &obs.SetBucketCorsInput{Bucket: input.Name}
+		corsInput.CorsRules = []obs.CorsRule{*corsRule}
+		if _, err = client.SetBucketCors(corsInput); err != nil {
+			logrus.Errorf("set obs bucket cors meet err=%v", err)
+			return err
+		}
+	}
+
+	if input.Policy != "" {
+		policyInput := &obs.SetBucketPolicyInput{
+			Bucket: input.Name,
+			Policy: input.Policy,
+		}
+		if _, err = client.SetBucketPolicy(policyInput); err != nil {
+			logrus.Errorf("set obs bucket policy meet err=%v", err)
+			return err
+		}
+	}
+
+	if input.SseAlgorithm != "" {
+		if err = setObsBucketEncryption(input.CloudProviderParam, input.Name, input.SseAlgorithm, input.SseKmsKeyId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createObsBucket(input ObsBucketCreateInput) (output ObsBucketCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkObsBucketCreateParams(input); err != nil {
+		return
+	}
+
+	client, err := createObsClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	if input.Id != "" {
+		exist := false
+		exist, err = isObsBucketExist(client, input.Id)
+		if err == nil && exist {
+			output.Id = input.Id
+			output.DomainName = input.Id + "." + getObsEndpoint(input.CloudProviderParam)
+			return
+		}
+	}
+
+	cloudMap, _ := GetMapFromString(input.CloudParams)
+	createInput := &obs.CreateBucketInput{
+		Bucket:       input.Name,
+		ACL:          obs.AclType(input.Acl),
+		StorageClass: obs.StorageClassType(input.StorageClass),
+	}
+	createInput.Location = cloudMap[CLOUD_PARAM_REGION]
+	if _, err = client.CreateBucket(createInput); err != nil {
+		obsError, ok := err.(obs.ObsError)
+		if !ok || obsError.Code != "BucketAlreadyOwnedByYou" {
+			logrus.Errorf("create obs bucket meet err=%v", err)
+			return
+		}
+		err = nil
+	}
+	output.Id = input.Name
+	output.DomainName = input.Name + "." + getObsEndpoint(input.CloudProviderParam)
+
+	err = setObsBucketConfiguration(client, input)
+	return
+}
+
+func (action *ObsBucketCreateAction) Do(inputs interface{}) (interface{}, error) {
+	buckets, _ := inputs.(ObsBucketCreateInputs)
+	outputs := ObsBucketCreateOutputs{}
+	var finalErr error
+
+	for _, input := range buckets.Inputs {
+		output, err := createObsBucket(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete obs bucket------------------//
+type ObsBucketDeleteInputs struct {
+	Inputs []ObsBucketDeleteInput `json:"inputs,omitempty"`
+}
+
+type ObsBucketDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	ForceEmpty string `json:"force_empty,omitempty"`
+}
+
+type ObsBucketDeleteOutputs struct {
+	Outputs []ObsBucketDeleteOutput `json:"outputs,omitempty"`
+}
+
+type ObsBucketDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type ObsBucketDeleteAction struct {
+}
+
+func (action *ObsBucketDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs ObsBucketDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteObsObjects(client *obs.ObsClient, bucketName string, objects []obs.ObjectToDelete) error {
+	for len(objects) > 0 {
+		num := len(objects)
+		if num > OBS_DELETE_OBJECTS_MAX_NUM {
+			num = OBS_DELETE_OBJECTS_MAX_NUM
+		}
+		resp, err := client.DeleteObjects(&obs.DeleteObjectsInput{
+			Bucket:  bucketName,
+			Quiet:   true,
+			Objects: objects[:num],
+		})
+		if err != nil {
+			logrus.Errorf("delete obs bucket(%v) objects meet err=%v", bucketName, err)
+			return err
+		}
+		if len(resp.Errors) > 0 {
+			return fmt.Errorf("delete obs object(%v) meet err=%v", resp.Errors[0].Key, resp.Errors[0].Message)
+		}
+		objects = objects[num:]
+	}
+	return nil
+}
+
+func emptyObsBucket(client *obs.ObsClient, bucketName string) error {
+	//delete all objects include history versions and delete markers
+	listInput := &obs.ListVersionsInput{Bucket: bucketName}
+	for {
+		resp, err := client.ListVersions(listInput)
+		if err != nil {
+			logrus.Errorf("list obs bucket(%v) versions meet err=%v", bucketName, err)
+			return err
+		}
+
+		objects := []obs.ObjectToDelete{}
+		for _, version := range resp.Versions {
+			objects = append(objects, obs.ObjectToDelete{Key: version.Key, VersionId: version.VersionId})
+		}
+		for _, marker := range resp.DeleteMarkers {
+			objects = append(objects, obs.ObjectToDelete{Key: marker.Key, VersionId: marker.VersionId})
+		}
+		if err = deleteObsObjects(client, bucketName, objects); err != nil {
+			return err
+		}
+
+		if !resp.IsTruncated {
+			break
+		}
+		listInput.KeyMarker = resp.NextKeyMarker
+		listInput.VersionIdMarker = resp.NextVersionIdMarker
+	}
+
+	//abort all unfinished multipart uploads
+	uploadsInput := &obs.ListMultipartUploadsInput{Bucket: bucketName}
+	for {
+		resp, err := client.ListMultipartUploads(uploadsInput)
+		if err != nil {
+			logrus.Errorf("list obs bucket(%v) multipart uploads meet err=%v", bucketName, err)
+			return err
+		}
+		for _, upload := range resp.Uploads {
+			if _, err = client.AbortMultipartUpload(&obs.AbortMultipartUploadInput{
+				Bucket:   bucketName,
+				Key:      upload.Key,
+				UploadId: upload.UploadId,
+			}); err != nil {
+				logrus.Errorf("abort obs multipart upload(%v) meet err=%v", upload.UploadId, err)
+				return err
+			}
+		}
+
+		if !resp.IsTruncated {
+			break
+		}
+		uploadsInput.KeyMarker = resp.NextKeyMarker
+		uploadsInput.UploadIdMarker = resp.NextUploadIdMarker
+	}
+	return nil
+}
+
+func deleteObsBucket(input ObsBucketDeleteInput) (output ObsBucketDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	if input.ForceEmpty != "" {
+		if err = isValidStringValue("force_empty", input.ForceEmpty, []string{"true", "false"}); err != nil {
+			return
+		}
+	}
+
+	client, err := createObsClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	exist, err := isObsBucketExist(client, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	if input.ForceEmpty == "true" {
+		if err = emptyObsBucket(client, input.Id); err != nil {
+			return
+		}
+	}
+
+	if _, err = client.DeleteBucket(input.Id); err != nil {
+		logrus.Errorf("delete obs bucket(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *ObsBucketDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	buckets, _ := inputs.(ObsBucketDeleteInputs)
+	outputs := ObsBucketDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range buckets.Inputs {
+		output, err := deleteObsBucket(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------query obs bucket------------------//
+type ObsBucketQueryInputs struct {
+	Inputs []ObsBucketQueryInput `json:"inputs,omitempty"`
+}
+
+type ObsBucketQueryInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type ObsBucketQueryOutputs struct {
+	Outputs []ObsBucketQueryOutput `json:"outputs,omitempty"`
+}
+
+type ObsBucketQueryOutput struct {
+	CallBackParameter
+	Result
+	Guid         string `json:"guid,omitempty"`
+	Id           string `json:"id,omitempty"`
+	DomainName   string `json:"domain_name,omitempty"`
+	Location     string `json:"location,omitempty"`
+	StorageClass string `json:"storage_class,omitempty"`
+	Versioning   string `json:"versioning,omitempty"`
+	Policy       string `json:"policy,omitempty"`
+	Size         string `json:"size,omitempty"`
+	ObjectNumber string `json:"object_number,omitempty"`
+}
+
+type ObsBucketQueryAction struct {
+}
+
+func (action *ObsBucketQueryAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs ObsBucketQueryInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func queryObsBucket(input ObsBucketQueryInput) (output ObsBucketQueryOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	client, err := createObsClient(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+
+	metadata, err := client.GetBucketMetadata(&obs.GetBucketMetadataInput{Bucket: input.Id})
+	if err != nil {
+		logrus.Errorf("get obs bucket(%v) metadata meet err=%v", input.Id, err)
+		return
+	}
+	output.DomainName = input.Id + "." + getObsEndpoint(input.CloudProviderParam)
+	output.Location = metadata.Location
+	output.StorageClass = string(metadata.StorageClass)
+
+	versioning, err := client.GetBucketVersioning(input.Id)
+	if err != nil {
+		logrus.Errorf("get obs bucket(%v) versioning meet err=%v", input.Id, err)
+		return
+	}
+	output.Versioning = string(versioning.Status)
+
+	storageInfo, err := client.GetBucketStorageInfo(input.Id)
+	if err != nil {
+		logrus.Errorf("get obs bucket(%v) storage info meet err=%v", input.Id, err)
+		return
+	}
+	output.Size = strconv.FormatInt(storageInfo.Size, 10)
+	output.ObjectNumber = strconv.Itoa(storageInfo.ObjectNumber)
+
+	policy, err := client.GetBucketPolicy(input.Id)
+	if err != nil {
+		//bucket without policy returns 404
+		if obsError, ok := err.(obs.ObsError); ok && obsError.StatusCode == http.StatusNotFound {
+			err = nil
+			return
+		}
+		logrus.Errorf("get obs bucket(%v) policy meet err=%v", input.Id, err)
+		return
+	}
+	output.Policy = policy.Policy
+	return
+}
+
+func (action *ObsBucketQueryAction) Do(inputs interface{}) (interface{}, error) {
+	buckets, _ := inputs.(ObsBucketQueryInputs)
+	outputs := ObsBucketQueryOutputs{}
+	var finalErr error
+
+	for _, input := range buckets.Inputs {
+		output, err := queryObsBucket(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
@@ -2,18 +2,32 @@ package plugins
 
 import (
 	"fmt"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/vpc/v2.0/peerings"
+	"github.com/gophercloud/gophercloud/openstack/vpc/v2.0/routes"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	PEERING_STATUS_ACTIVE             = "ACTIVE"
+	PEERING_STATUS_PENDING_ACCEPTANCE = "PENDING_ACCEPTANCE"
+	PEERING_STATUS_REJECTED           = "REJECTED"
+	PEERING_STATUS_EXPIRED            = "EXPIRED"
+
+	PEERING_ROUTE_TYPE = "peering"
+)
+
 var peeringsActions = make(map[string]Action)
 
 func init() {
 	peeringsActions["create"] = new(PeeringsCreateAction)
 	peeringsActions["delete"] = new(PeeringsDeleteAction)
+	peeringsActions["accept"] = new(PeeringsAcceptAction)
+	peeringsActions["reject"] = new(PeeringsRejectAction)
 }
 
 type PeeringsPlugin struct {
@@ -40,6 +54,12 @@ type PeeringsCreateInput struct {
 	Name       string `json:"name,omitempty"`
 	LocalVpcId string `json:"local_vpc_id,omitempty"`
 	PeerVpcId  string `json:"peer_vpc_id,omitempty"`
+
+	// the peer vpc belongs to another project or account if not empty
+	PeerProjectId string `json:"peer_project_id,omitempty"`
+	// the peer vpc of another project can't be read, so its cidr is given for the route
+	PeerVpcCidr string `json:"peer_vpc_cidr,omitempty"`
+	AutoRoute   string `json:"auto_route,omitempty"`
 }
 
 type PeeringsCreateOutputs struct {
@@ -49,8 +69,9 @@ type PeeringsCreateOutputs struct {
 type PeeringsCreateOutput struct {
 	CallBackParameter
 	Result
-	Guid string `json:"guid,omitempty"`
-	Id   string `json:"id,omitempty"`
+	Guid   string `json:"guid,omitempty"`
+	Id     string `json:"id,omitempty"`
+	Status string `json:"status,omitempty"`
 }
 
 type PeeringsCreateAction struct {
@@ -79,6 +100,163 @@ func checkPeeringsCreateParam(input PeeringsCreateInput) error {
 	if input.PeerVpcId == "" {
 		return fmt.Errorf("peerVpcId is empty")
 	}
+	return checkPeeringsRouteParam(input.AutoRoute, input.PeerVpcCidr, input.PeerProjectId != "")
+}
+
+func checkPeeringsRouteParam(autoRoute string, peerVpcCidr string, isCrossProject bool) error {
+	if err := isValidStringValue("auto_route", strings.ToLower(autoRoute), []string{"", "true", "false"}); err != nil {
+		return err
+	}
+	if peerVpcCidr != "" {
+		if _, _, err := net.ParseCIDR(peerVpcCidr); err != nil {
+			return fmt.Errorf("peerVpcCidr(%v) is invalid", peerVpcCidr)
+		}
+	}
+	if strings.ToLower(autoRoute) == "true" && isCrossProject && peerVpcCidr == "" {
+		return fmt.Errorf("peerVpcCidr is empty, it's needed by the route of the cross project peering")
+	}
+	return nil
+}
+
+func getPeering(sc *gophercloud.ServiceClient, id string) (*peerings.Peering, error) {
+	peering, err := peerings.Get(sc, id).Extract()
+	if err != nil {
+		logrus.Errorf("get peering(%v) meet err=%v", id, err)
+	}
+	return peering, err
+}
+
+// the peering of the same project is active at once, the cross project one is pending until the peer accepts
+func waitPeeringStatus(sc *gophercloud.ServiceClient, id string, expectedStatuses []string) (*peerings.Peering, error) {
+	count := 0
+	for {
+		peering, err := getPeering(sc, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range expectedStatuses {
+			if peering.Status == status {
+				return peering, nil
+			}
+		}
+		if peering.Status == PEERING_STATUS_REJECTED || peering.Status == PEERING_STATUS_EXPIRED {
+			return peering, fmt.Errorf("peering(%v) status is %v", id, peering.Status)
+		}
+
+		count++
+		if count > 30 {
+			return peering, fmt.Errorf("after %vs, peering(%v) status is %v, expected %v", count*2, id, peering.Status, expectedStatuses)
+		}
+		time.Sleep(time.Second * 2)
+	}
+}
+
+func getVpcCidr(param CloudProviderParam, vpcId string) (string, error) {
+	sc, err := CreateVpcServiceClientV1(param)
+	if err != nil {
+		return "", err
+	}
+	vpc, exist, err := isVpcExist(sc, vpcId)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", fmt.Errorf("vpc(%v) is not exist", vpcId)
+	}
+	return vpc.Cidr, nil
+}
+
+// the route is added to the default route table of the vpc, an existing one with the same destination is reused
+func addPeeringRoute(param CloudProviderParam, peeringId string, vpcId string, destination string) error {
+	sc, err := CreateVpcServiceClientV1(param)
+	if err != nil {
+		return err
+	}
+	allPages, err := routes.List(sc, routes.ListOpts{VpcID: vpcId, Destination: destination}).AllPages()
+	if err != nil {
+		logrus.Errorf("list vpc(%v) routes meet err=%v", vpcId, err)
+		return err
+	}
+	vpcRoutes, err := routes.ExtractRoutes(allPages)
+	if err != nil {
+		return err
+	}
+	for _, route := range vpcRoutes {
+		if route.Destination != destination {
+			continue
+		}
+		if route.Nexthop == peeringId {
+			return nil
+		}
+		return fmt.Errorf("vpc(%v) already has route(%v) to %v %v", vpcId, destination, route.Type, route.Nexthop)
+	}
+
+	_, err = routes.Create(sc, routes.CreateOpts{
+		Type:        PEERING_ROUTE_TYPE,
+		Nexthop:     peeringId,
+		Destination: destination,
+		VpcID:       vpcId,
+	}).Extract()
+	if err != nil {
+		logrus.Errorf("create vpc(%v) route(%v) to peering(%v) meet err=%v", vpcId, destination, peeringId, err)
+	}
+	return err
+}
+
+// routes to the peering should be deleted before deleting the peering,
+// both in the default route table and in the custom ones
+func deletePeeringRoutes(param CloudProviderParam, peeringId string, vpcId string) error {
+	sc, err := CreateVpcServiceClientV1(param)
+	if err != nil {
+		return err
+	}
+	allPages, err := routes.List(sc, routes.ListOpts{VpcID: vpcId, Type: PEERING_ROUTE_TYPE}).AllPages()
+	if err != nil {
+		logrus.Errorf("list vpc(%v) routes meet err=%v", vpcId, err)
+		return err
+	}
+	vpcRoutes, err := routes.ExtractRoutes(allPages)
+	if err != nil {
+		return err
+	}
+	for _, route := range vpcRoutes {
+		if route.Nexthop != peeringId {
+			continue
+		}
+		if err = routes.Delete(sc, route.ID).ExtractErr(); err != nil {
+			logrus.Errorf("delete vpc(%v) route(%v) meet err=%v", vpcId, route.ID, err)
+			return err
+		}
+	}
+
+	routeTables, err := listRouteTables(sc, vpcId)
+	if err != nil {
+		return err
+	}
+	for _, table := range routeTables {
+		if table.Default {
+			continue
+		}
+		routeTable, exist, err := getRouteTableById(sc, table.Id)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			continue
+		}
+		delRoutes := []routeTableRoute{}
+		for _, route := range routeTable.Routes {
+			if route.Type == ROUTE_TABLE_NEXTHOP_PEERING && route.Nexthop == peeringId {
+				delRoutes = append(delRoutes, route)
+			}
+		}
+		if len(delRoutes) == 0 {
+			continue
+		}
+		if err = updateRouteTableRoutes(sc, table.Id, nil, delRoutes); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -124,26 +302,62 @@ func createPeerings(input PeeringsCreateInput) (output PeeringsCreateOutput, err
 		}
 		if exist {
 			output.Id = input.Id
+		}
+	}
+
+	if output.Id == "" {
+		opts := peerings.CreateOpts{
+			Name: input.Name,
+			RequestVpcInfo: peerings.VPCInfo{
+				VpcID: input.LocalVpcId,
+			},
+			AcceptVpcInfo: peerings.VPCInfo{
+				VpcID:    input.PeerVpcId,
+				TenantID: input.PeerProjectId,
+			},
+		}
+
+		result, createErr := peerings.Create(sc, opts).Extract()
+		if createErr != nil {
+			err = createErr
 			return
 		}
+		output.Id = result.ID
 	}
 
-	opts := peerings.CreateOpts{
-		Name: input.Name,
-		RequestVpcInfo: peerings.VPCInfo{
-			VpcID: input.LocalVpcId,
-		},
-		AcceptVpcInfo: peerings.VPCInfo{
-			VpcID: input.PeerVpcId,
-		},
+	// the cross project peering returns at pending acceptance, the peer's accept action adds the route of the peer vpc,
+	// after that run create again with the id to add the route of the local vpc
+	expectedStatuses := []string{PEERING_STATUS_ACTIVE}
+	if input.PeerProjectId != "" {
+		expectedStatuses = append(expectedStatuses, PEERING_STATUS_PENDING_ACCEPTANCE)
+	}
+	peering, err := waitPeeringStatus(sc, output.Id, expectedStatuses)
+	if peering != nil {
+		output.Status = peering.Status
+	}
+	if err != nil {
+		return
 	}
 
-	result, createErr := peerings.Create(sc, opts).Extract()
-	if createErr != nil {
-		err = createErr
+	if strings.ToLower(input.AutoRoute) != "true" || peering.Status != PEERING_STATUS_ACTIVE {
+		return
+	}
+	peerVpcCidr := input.PeerVpcCidr
+	if peerVpcCidr == "" {
+		if peerVpcCidr, err = getVpcCidr(input.CloudProviderParam, input.PeerVpcId); err != nil {
+			return
+		}
+	}
+	if err = addPeeringRoute(input.CloudProviderParam, output.Id, input.LocalVpcId, peerVpcCidr); err != nil {
 		return
 	}
-	output.Id = result.ID
+	if input.PeerProjectId == "" {
+		localVpcCidr := ""
+		if localVpcCidr, err = getVpcCidr(input.CloudProviderParam, input.LocalVpcId); err != nil {
+			return
+		}
+		err = addPeeringRoute(input.CloudProviderParam, output.Id, input.PeerVpcId, localVpcCidr)
+	}
 	return
 }
 
@@ -229,6 +443,21 @@ func deletePeerings(input PeeringsDeleteInput) (output PeeringsDeleteOutput, err
 		return
 	}
 
+	// only the routes of the vpcs in this project can be deleted
+	peering, err := getPeering(sc, input.Id)
+	if err != nil {
+		return
+	}
+	cloudMap, _ := GetMapFromString(input.CloudParams)
+	for _, vpcInfo := range []peerings.VPCInfo{peering.RequestVpcInfo, peering.AcceptVpcInfo} {
+		if vpcInfo.TenantID != "" && vpcInfo.TenantID != cloudMap[CLOUD_PARAM_PROJECT_ID] {
+			continue
+		}
+		if err = deletePeeringRoutes(input.CloudProviderParam, input.Id, vpcInfo.VpcID); err != nil {
+			return
+		}
+	}
+
 	err = peerings.Delete(sc, input.Id).ExtractErr()
 	if err != nil {
 		logrus.Errorf("delete peerings[id=%v] failed, error=%v", input.Id, err)
@@ -252,3 +481,159 @@ func (action *PeeringsDeleteAction) Do(inputs interface{}) (interface{}, error)
 	logrus.Infof("all peerings = %v are deleted", peerings)
 	return &outputs, finalErr
 }
+
+//--------------accept and reject peering------------------//
+// the actions are run with the cloud provider param of the peer side
+type PeeringsAcceptInputs struct {
+	Inputs []PeeringsAcceptInput `json:"inputs,omitempty"`
+}
+
+type PeeringsAcceptInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+
+	// the cidr of the requester vpc, used by the route of the accepter vpc
+	PeerVpcCidr string `json:"peer_vpc_cidr,omitempty"`
+	AutoRoute   string `json:"auto_route,omitempty"`
+}
+
+type PeeringsAcceptOutputs struct {
+	Outputs []PeeringsAcceptOutput `json:"outputs,omitempty"`
+}
+
+type PeeringsAcceptOutput struct {
+	CallBackParameter
+	Result
+	Guid   string `json:"guid,omitempty"`
+	Id     string `json:"id,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+type PeeringsAcceptAction struct {
+}
+
+func (action *PeeringsAcceptAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs PeeringsAcceptInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+type PeeringsRejectAction struct {
+}
+
+func (action *PeeringsRejectAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs PeeringsAcceptInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func acceptOrRejectPeerings(input PeeringsAcceptInput, isAccept bool) (output PeeringsAcceptOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("empty peer id")
+		return
+	}
+	if isAccept {
+		if err = checkPeeringsRouteParam(input.AutoRoute, input.PeerVpcCidr, true); err != nil {
+			return
+		}
+	}
+
+	sc, err := createVpcServiceClientV2(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	peering, err := getPeering(sc, input.Id)
+	if err != nil {
+		return
+	}
+
+	expectedStatus := PEERING_STATUS_REJECTED
+	if isAccept {
+		expectedStatus = PEERING_STATUS_ACTIVE
+	}
+	if peering.Status != expectedStatus {
+		if peering.Status != PEERING_STATUS_PENDING_ACCEPTANCE {
+			err = fmt.Errorf("peering(%v) status is %v, can't be accepted or rejected", input.Id, peering.Status)
+			return
+		}
+		if isAccept {
+			_, err = peerings.Accept(sc, input.Id).Extract()
+		} else {
+			_, err = peerings.Reject(sc, input.Id).Extract()
+		}
+		if err != nil {
+			logrus.Errorf("accept or reject peering(%v) meet err=%v", input.Id, err)
+			return
+		}
+	}
+	if !isAccept {
+		output.Status = PEERING_STATUS_REJECTED
+		return
+	}
+
+	if peering, err = waitPeeringStatus(sc, input.Id, []string{PEERING_STATUS_ACTIVE}); err != nil {
+		return
+	}
+	output.Status = peering.Status
+	if strings.ToLower(input.AutoRoute) == "true" {
+		err = addPeeringRoute(input.CloudProviderParam, input.Id, peering.AcceptVpcInfo.VpcID, input.PeerVpcCidr)
+	}
+	return
+}
+
+func (action *PeeringsAcceptAction) Do(inputs interface{}) (interface{}, error) {
+	peerings, _ := inputs.(PeeringsAcceptInputs)
+	outputs := PeeringsAcceptOutputs{}
+	var finalErr error
+
+	for _, input := range peerings.Inputs {
+		output, err := acceptOrRejectPeerings(input, true)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all peerings = %v are accepted", peerings)
+	return &outputs, finalErr
+}
+
+func (action *PeeringsRejectAction) Do(inputs interface{}) (interface{}, error) {
+	peerings, _ := inputs.(PeeringsAcceptInputs)
+	outputs := PeeringsAcceptOutputs{}
+	var finalErr error
+
+	for _, input := range peerings.Inputs {
+		output, err := acceptOrRejectPeerings(input, false)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all peerings = %v are rejected", peerings)
+	return &outputs, finalErr
+}
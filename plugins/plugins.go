@@ -60,10 +60,27 @@ func init() {
 	RegisterPlugin("public-ip", new(PublicIpPlugin))
 	RegisterPlugin("nat-gateway", new(NatPlugin))
 	RegisterPlugin("nat-snat-rule", new(SnatRulePlugin))
+	RegisterPlugin("nat-dnat-rule", new(DnatRulePlugin))
 	RegisterPlugin("route", new(RoutePlugin))
 	RegisterPlugin("rds", new(RdsPlugin))
 	RegisterPlugin("dcs", new(DcsPlugin))
 	RegisterPlugin("lb-whitelist", new(LbWhitelistPlugin))
+	RegisterPlugin("obs-bucket", new(ObsBucketPlugin))
+	RegisterPlugin("rds-database", new(RdsDatabasePlugin))
+	RegisterPlugin("rds-account", new(RdsAccountPlugin))
+	RegisterPlugin("rds-parameter-group", new(RdsParameterGroupPlugin))
+	RegisterPlugin("dds", new(DdsPlugin))
+	RegisterPlugin("dms", new(DmsPlugin))
+	RegisterPlugin("lb-listener", new(LbListenerPlugin))
+	RegisterPlugin("lb-pool", new(LbPoolPlugin))
+	RegisterPlugin("lb-health-monitor", new(LbHealthMonitorPlugin))
+	RegisterPlugin("lb-certificate", new(LbCertificatePlugin))
+	RegisterPlugin("lb-l7policy", new(LbL7PolicyPlugin))
+	RegisterPlugin("lb-l7rule", new(LbL7RulePlugin))
+	RegisterPlugin("network-acl", new(NetworkAclPlugin))
+	RegisterPlugin("route-table", new(RouteTablePlugin))
+	RegisterPlugin("vip", new(VipPlugin))
+	RegisterPlugin("shared-bandwidth", new(SharedBandwidthPlugin))
 }
 
 type PluginRequest struct {
@@ -0,0 +1,309 @@
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// the policy file is checked before security group rules and lb whitelists are sent to the cloud,
+// the default path is relative to the working directory, no policy is applied if the file does not exist.
+// see conf/policy.json.sample, e.g.
+// {
+//   "mode": "enforce",
+//   "rules": [
+//     {"name": "no public admin ports", "direction": "ingress", "ports": "22,3389,3306", "allowed_remotes": "10.0.0.0/8,172.16.0.0/12,192.168.0.0/16"},
+//     {"name": "max cidr width", "min_prefix_length": 16},
+//     {"name": "approved egress", "direction": "egress", "allowed_remotes": "10.0.0.0/8", "mode": "audit"}
+//   ]
+// }
+const (
+	POLICY_FILE_ENV          = "HUAWEI_PLUGIN_POLICY_FILE"
+	POLICY_FILE_DEFAULT_PATH = "conf/policy.json"
+
+	POLICY_MODE_ENFORCE = "enforce"
+	POLICY_MODE_AUDIT   = "audit"
+
+	POLICY_TARGET_SECURITY_GROUP_RULE = "security-group-rule"
+	POLICY_TARGET_LB_WHITELIST        = "lb-whitelist"
+)
+
+type PolicyConfig struct {
+	Mode  string       `json:"mode"` //enforce or audit, default enforce
+	Rules []PolicyRule `json:"rules"`
+}
+
+// empty fields match everything
+type PolicyRule struct {
+	Name                string `json:"name"`
+	Mode                string `json:"mode"`      //overwrite the mode of the config
+	Targets             string `json:"targets"`   //security-group-rule,lb-whitelist
+	Direction           string `json:"direction"` //lb whitelist is ingress
+	Protocols           string `json:"protocols"`
+	Ports               string `json:"ports"`           //22,3306,8000-9000
+	AllowedRemotes      string `json:"allowed_remotes"` //remote should be inside one of the cidrs
+	DeniedRemotes       string `json:"denied_remotes"`  //remote should not overlap any of the cidrs
+	MinPrefixLength     int    `json:"min_prefix_length"`
+	MinIpv6PrefixLength int    `json:"min_ipv6_prefix_length"`
+}
+
+// the requested access, port range is -1 if all ports
+type policyRequest struct {
+	Target         string
+	Direction      string
+	Protocol       string
+	PortMin        int
+	PortMax        int
+	RemoteIpPrefix string
+}
+
+func (request policyRequest) String() string {
+	protocol := request.Protocol
+	if protocol == "" {
+		protocol = RULE_PROTOCOL_ANY
+	}
+	port := "all"
+	if request.PortMin >= 0 {
+		port = fmt.Sprintf("%v", request.PortMin)
+		if request.PortMax >= 0 && request.PortMax != request.PortMin {
+			port = fmt.Sprintf("%v-%v", request.PortMin, request.PortMax)
+		}
+	}
+	return fmt.Sprintf("%v %v %v %v", request.Direction, protocol, port, request.RemoteIpPrefix)
+}
+
+func loadPolicyConfig() (*PolicyConfig, error) {
+	path := os.Getenv(POLICY_FILE_ENV)
+	if path == "" {
+		path = POLICY_FILE_DEFAULT_PATH
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read policy file(%v) meet err=%v", path, err)
+	}
+
+	config := PolicyConfig{}
+	if err = json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("policy file(%v) is invalid, err=%v", path, err)
+	}
+	if err = checkPolicyConfig(config); err != nil {
+		return nil, fmt.Errorf("policy file(%v) is invalid, err=%v", path, err)
+	}
+	return &config, nil
+}
+
+func checkPolicyConfig(config PolicyConfig) error {
+	validModes := []string{"", POLICY_MODE_ENFORCE, POLICY_MODE_AUDIT}
+	if err := isValidStringValue("mode", config.Mode, validModes); err != nil {
+		return err
+	}
+	for _, rule := range config.Rules {
+		if err := isValidStringValue(fmt.Sprintf("rule(%v) mode", rule.Name), rule.Mode, validModes); err != nil {
+			return err
+		}
+		if _, err := getPolicyPortRanges(rule.Ports); err != nil {
+			return fmt.Errorf("rule(%v) %v", rule.Name, err)
+		}
+		for _, remotes := range []string{rule.AllowedRemotes, rule.DeniedRemotes} {
+			if _, err := getPolicyCidrs(remotes); err != nil {
+				return fmt.Errorf("rule(%v) %v", rule.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func getPolicyPortRanges(ports string) ([][2]int, error) {
+	portRanges := [][2]int{}
+	entries, err := GetArrayFromString(ports, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return portRanges, err
+	}
+	for _, entry := range entries {
+		portMin, portMax, err := getPortMinAndMax(entry)
+		if err != nil {
+			return portRanges, err
+		}
+		portRanges = append(portRanges, [2]int{portMin, portMax})
+	}
+	return portRanges, nil
+}
+
+func getPolicyCidrs(remotes string) ([]*net.IPNet, error) {
+	cidrs := []*net.IPNet{}
+	entries, err := GetArrayFromString(remotes, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return cidrs, err
+	}
+	for _, entry := range entries {
+		_, cidr, err := net.ParseCIDR(entry)
+		if err != nil {
+			return cidrs, fmt.Errorf("cidr(%v) is invalid", entry)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
+
+func isCidrInside(inner *net.IPNet, outer *net.IPNet) bool {
+	innerOnes, innerBits := inner.Mask.Size()
+	outerOnes, outerBits := outer.Mask.Size()
+	return innerBits == outerBits && innerOnes >= outerOnes && outer.Contains(inner.IP)
+}
+
+func isCidrOverlap(first *net.IPNet, second *net.IPNet) bool {
+	return first.Contains(second.IP) || second.Contains(first.IP)
+}
+
+func isPolicyRuleMatched(rule PolicyRule, request policyRequest) bool {
+	if rule.Targets != "" && !strings.Contains(","+rule.Targets+",", ","+request.Target+",") {
+		return false
+	}
+	if rule.Direction != "" && !strings.EqualFold(rule.Direction, request.Direction) {
+		return false
+	}
+	if rule.Protocols != "" && request.Protocol != "" {
+		protocols, _ := GetArrayFromString(strings.ToLower(rule.Protocols), ARRAY_SIZE_REAL, 0)
+		if err := isValidStringValue("protocol", request.Protocol, protocols); err != nil {
+			return false
+		}
+	}
+	// the port range of icmp is the type and code, the port rule only covers icmp listed in its protocols
+	if rule.Ports != "" && (request.Protocol == RULE_PROTOCOL_ICMP || request.Protocol == RULE_PROTOCOL_ICMPV6) {
+		return rule.Protocols != ""
+	}
+	if rule.Ports != "" && request.PortMin >= 0 {
+		portRanges, _ := getPolicyPortRanges(rule.Ports)
+		for _, portRange := range portRanges {
+			if request.PortMin <= portRange[1] && request.PortMax >= portRange[0] {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func getPolicyViolation(rule PolicyRule, request policyRequest) string {
+	_, remote, err := net.ParseCIDR(request.RemoteIpPrefix)
+	if err != nil {
+		// remote security group is not limited by the cidr rules
+		return ""
+	}
+
+	ones, bits := remote.Mask.Size()
+	if bits == 32 && rule.MinPrefixLength > 0 && ones < rule.MinPrefixLength {
+		return fmt.Sprintf("prefix length of %v is less than %v", request.RemoteIpPrefix, rule.MinPrefixLength)
+	}
+	if bits == 128 && rule.MinIpv6PrefixLength > 0 && ones < rule.MinIpv6PrefixLength {
+		return fmt.Sprintf("prefix length of %v is less than %v", request.RemoteIpPrefix, rule.MinIpv6PrefixLength)
+	}
+
+	deniedCidrs, _ := getPolicyCidrs(rule.DeniedRemotes)
+	for _, cidr := range deniedCidrs {
+		if isCidrOverlap(remote, cidr) {
+			return fmt.Sprintf("%v overlaps the denied %v", request.RemoteIpPrefix, cidr.String())
+		}
+	}
+
+	allowedCidrs, _ := getPolicyCidrs(rule.AllowedRemotes)
+	if len(allowedCidrs) == 0 {
+		return ""
+	}
+	for _, cidr := range allowedCidrs {
+		if isCidrInside(remote, cidr) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%v is not inside the allowed remotes", request.RemoteIpPrefix)
+}
+
+// violations of enforce rules are returned as error, violations of audit rules are only logged
+func checkPolicy(requests []policyRequest) error {
+	config, err := loadPolicyConfig()
+	if err != nil {
+		logrus.Errorf("load policy meet err=%v", err)
+		return err
+	}
+	if config == nil {
+		return nil
+	}
+
+	violations := []string{}
+	for _, request := range requests {
+		for _, rule := range config.Rules {
+			if !isPolicyRuleMatched(rule, request) {
+				continue
+			}
+			violation := getPolicyViolation(rule, request)
+			if violation == "" {
+				continue
+			}
+
+			mode := config.Mode
+			if rule.Mode != "" {
+				mode = rule.Mode
+			}
+			if mode == POLICY_MODE_AUDIT {
+				logrus.Warnf("%v(%v) violates policy(%v): %v, allowed by audit mode", request.Target, request, rule.Name, violation)
+				continue
+			}
+			violations = append(violations, fmt.Sprintf("%v(%v) violates policy(%v): %v", request.Target, request, rule.Name, violation))
+		}
+	}
+
+	if len(violations) > 0 {
+		logrus.Errorf("policy check failed, violations=%v", violations)
+		return fmt.Errorf("%v", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+func checkSecurityGroupRulePolicy(rules []securityGroupRuleSpec) error {
+	requests := []policyRequest{}
+	for _, rule := range rules {
+		remote := rule.RemoteIpPrefix
+		if rule.RemoteGroupId != "" {
+			remote = "sg:" + rule.RemoteGroupId
+		}
+		requests = append(requests, policyRequest{
+			Target:         POLICY_TARGET_SECURITY_GROUP_RULE,
+			Direction:      rule.Direction,
+			Protocol:       rule.Protocol,
+			PortMin:        rule.PortMin,
+			PortMax:        rule.PortMax,
+			RemoteIpPrefix: remote,
+		})
+	}
+	return checkPolicy(requests)
+}
+
+// the listener port is unknown before calling the cloud, so the whitelist is checked as all ports
+func checkLbWhitelistPolicy(ips []string) error {
+	requests := []policyRequest{}
+	for _, ip := range ips {
+		if !strings.Contains(ip, "/") {
+			if strings.Contains(ip, ":") {
+				ip = ip + "/128"
+			} else {
+				ip = ip + "/32"
+			}
+		}
+		requests = append(requests, policyRequest{
+			Target:         POLICY_TARGET_LB_WHITELIST,
+			Direction:      RULE_DIRECTION_INGRESS,
+			PortMin:        -1,
+			PortMax:        -1,
+			RemoteIpPrefix: ip,
+		})
+	}
+	return checkPolicy(requests)
+}
@@ -0,0 +1,131 @@
+package plugins
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestIsPolicyRuleMatched(t *testing.T) {
+	tcp22 := policyRequest{Target: POLICY_TARGET_SECURITY_GROUP_RULE, Direction: "ingress", Protocol: "tcp", PortMin: 22, PortMax: 22, RemoteIpPrefix: "0.0.0.0/0"}
+	tcpRange := policyRequest{Target: POLICY_TARGET_SECURITY_GROUP_RULE, Direction: "ingress", Protocol: "tcp", PortMin: 3000, PortMax: 3400, RemoteIpPrefix: "0.0.0.0/0"}
+	anyProtocol := policyRequest{Target: POLICY_TARGET_SECURITY_GROUP_RULE, Direction: "ingress", PortMin: -1, PortMax: -1, RemoteIpPrefix: "0.0.0.0/0"}
+	icmp := policyRequest{Target: POLICY_TARGET_SECURITY_GROUP_RULE, Direction: "ingress", Protocol: "icmp", PortMin: 8, PortMax: 0, RemoteIpPrefix: "0.0.0.0/0"}
+	whitelist := policyRequest{Target: POLICY_TARGET_LB_WHITELIST, Direction: "ingress", PortMin: -1, PortMax: -1, RemoteIpPrefix: "1.2.3.4/32"}
+
+	cases := []struct {
+		name     string
+		rule     PolicyRule
+		request  policyRequest
+		expected bool
+	}{
+		{"empty rule matches all", PolicyRule{}, tcp22, true},
+		{"port matched", PolicyRule{Ports: "22,3389"}, tcp22, true},
+		{"port not matched", PolicyRule{Ports: "80,443"}, tcp22, false},
+		{"port range overlaps rule port", PolicyRule{Ports: "3306"}, tcpRange, true},
+		{"port range overlaps rule range", PolicyRule{Ports: "3400-3500"}, tcpRange, true},
+		{"port range not overlapped", PolicyRule{Ports: "3401-3500"}, tcpRange, false},
+		{"all ports overlap every rule port", PolicyRule{Ports: "22"}, anyProtocol, true},
+		{"icmp type is not a port", PolicyRule{Ports: "22"}, icmp, false},
+		{"icmp listed in the port rule protocols", PolicyRule{Protocols: "tcp,icmp", Ports: "22"}, icmp, true},
+		{"protocol matched", PolicyRule{Protocols: "TCP,udp"}, tcp22, true},
+		{"protocol not matched", PolicyRule{Protocols: "udp"}, tcp22, false},
+		{"any protocol matches the protocol rule", PolicyRule{Protocols: "udp"}, anyProtocol, true},
+		{"direction not matched", PolicyRule{Direction: "egress"}, tcp22, false},
+		{"direction ignore case", PolicyRule{Direction: "INGRESS"}, tcp22, true},
+		{"target matched", PolicyRule{Targets: "security-group-rule,lb-whitelist"}, whitelist, true},
+		{"target not matched", PolicyRule{Targets: "security-group-rule"}, whitelist, false},
+		{"target is not a prefix match", PolicyRule{Targets: "lb"}, whitelist, false},
+	}
+
+	for _, c := range cases {
+		if matched := isPolicyRuleMatched(c.rule, c.request); matched != c.expected {
+			t.Errorf("%v: isPolicyRuleMatched=%v, expected %v", c.name, matched, c.expected)
+		}
+	}
+}
+
+func TestGetPolicyViolation(t *testing.T) {
+	newRequest := func(remote string) policyRequest {
+		return policyRequest{Target: POLICY_TARGET_SECURITY_GROUP_RULE, Direction: "ingress", Protocol: "tcp", PortMin: 22, PortMax: 22, RemoteIpPrefix: remote}
+	}
+
+	cases := []struct {
+		name        string
+		rule        PolicyRule
+		remote      string
+		isViolation bool
+	}{
+		{"ipv4 prefix too short", PolicyRule{MinPrefixLength: 16}, "10.0.0.0/8", true},
+		{"ipv4 prefix long enough", PolicyRule{MinPrefixLength: 16}, "10.1.0.0/16", false},
+		{"ipv4 limit skips ipv6", PolicyRule{MinPrefixLength: 16}, "fd00::/8", false},
+		{"ipv6 prefix too short", PolicyRule{MinIpv6PrefixLength: 64}, "fd00::/8", true},
+		{"ipv6 prefix long enough", PolicyRule{MinIpv6PrefixLength: 64}, "fd00:1:2:3::/64", false},
+		{"ipv6 limit skips ipv4", PolicyRule{MinIpv6PrefixLength: 64}, "0.0.0.0/0", false},
+		{"denied overlapped by wider remote", PolicyRule{DeniedRemotes: "100.64.0.0/10"}, "0.0.0.0/0", true},
+		{"denied overlapped by narrower remote", PolicyRule{DeniedRemotes: "100.64.0.0/10"}, "100.64.1.1/32", true},
+		{"denied not overlapped", PolicyRule{DeniedRemotes: "100.64.0.0/10"}, "10.0.0.0/8", false},
+		{"allowed contains remote", PolicyRule{AllowedRemotes: "10.0.0.0/8,192.168.0.0/16"}, "192.168.1.0/24", false},
+		{"allowed same as remote", PolicyRule{AllowedRemotes: "10.0.0.0/8"}, "10.0.0.0/8", false},
+		{"remote wider than allowed", PolicyRule{AllowedRemotes: "10.0.0.0/8"}, "0.0.0.0/0", true},
+		{"remote outside allowed", PolicyRule{AllowedRemotes: "10.0.0.0/8"}, "172.16.0.0/12", true},
+		{"ipv6 remote outside ipv4 allowed", PolicyRule{AllowedRemotes: "0.0.0.0/0"}, "::/0", true},
+		{"remote security group skips cidr rules", PolicyRule{MinPrefixLength: 16, AllowedRemotes: "10.0.0.0/8"}, "sg:sg-1", false},
+		{"empty rule", PolicyRule{}, "0.0.0.0/0", false},
+	}
+
+	for _, c := range cases {
+		violation := getPolicyViolation(c.rule, newRequest(c.remote))
+		if (violation != "") != c.isViolation {
+			t.Errorf("%v: violation=%v, expected violation=%v", c.name, violation, c.isViolation)
+		}
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	publicSsh := policyRequest{Target: POLICY_TARGET_SECURITY_GROUP_RULE, Direction: "ingress", Protocol: "tcp", PortMin: 22, PortMax: 22, RemoteIpPrefix: "0.0.0.0/0"}
+	privateSsh := policyRequest{Target: POLICY_TARGET_SECURITY_GROUP_RULE, Direction: "ingress", Protocol: "tcp", PortMin: 22, PortMax: 22, RemoteIpPrefix: "10.0.0.0/8"}
+	rule := `{"name": "no public ssh", "ports": "22", "allowed_remotes": "10.0.0.0/8"%v}`
+
+	cases := []struct {
+		name     string
+		policy   string
+		requests []policyRequest
+		isErr    bool
+	}{
+		{"enforce by default", `{"rules": [` + fmt.Sprintf(rule, "") + `]}`, []policyRequest{publicSsh}, true},
+		{"enforce allows compliant", `{"mode": "enforce", "rules": [` + fmt.Sprintf(rule, "") + `]}`, []policyRequest{privateSsh}, false},
+		{"enforce with one violation", `{"mode": "enforce", "rules": [` + fmt.Sprintf(rule, "") + `]}`, []policyRequest{privateSsh, publicSsh}, true},
+		{"audit config", `{"mode": "audit", "rules": [` + fmt.Sprintf(rule, "") + `]}`, []policyRequest{publicSsh}, false},
+		{"audit rule in enforce config", `{"mode": "enforce", "rules": [` + fmt.Sprintf(rule, `, "mode": "audit"`) + `]}`, []policyRequest{publicSsh}, false},
+		{"enforce rule in audit config", `{"mode": "audit", "rules": [` + fmt.Sprintf(rule, `, "mode": "enforce"`) + `]}`, []policyRequest{publicSsh}, true},
+		{"invalid mode", `{"mode": "block", "rules": []}`, []policyRequest{privateSsh}, true},
+		{"invalid cidr", `{"rules": [{"name": "bad", "allowed_remotes": "10.0.0.0/33"}]}`, []policyRequest{privateSsh}, true},
+		{"invalid json", `{"rules": `, []policyRequest{privateSsh}, true},
+	}
+
+	defer os.Unsetenv(POLICY_FILE_ENV)
+	for _, c := range cases {
+		file, err := ioutil.TempFile("", "policy*.json")
+		if err != nil {
+			t.Fatalf("create policy file meet err=%v", err)
+		}
+		defer os.Remove(file.Name())
+		if _, err = file.WriteString(c.policy); err != nil {
+			t.Fatalf("write policy file meet err=%v", err)
+		}
+		file.Close()
+
+		os.Setenv(POLICY_FILE_ENV, file.Name())
+		if err = checkPolicy(c.requests); (err != nil) != c.isErr {
+			t.Errorf("%v: err=%v, expected err=%v", c.name, err, c.isErr)
+		}
+	}
+
+	// no policy is applied without the file
+	os.Setenv(POLICY_FILE_ENV, os.TempDir()+"/not-exist-policy.json")
+	if err := checkPolicy([]policyRequest{publicSsh}); err != nil {
+		t.Errorf("no policy file: err=%v, expected nil", err)
+	}
+}
@@ -0,0 +1,247 @@
+package plugins
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/openstack/vpc/v1/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// the nic is chosen by its private ip if the vm has more than one
+func getVmPortId(params CloudProviderParam, vmId string, nicIp string) (string, error) {
+	sc, err := CreateVpcServiceClientV1(params)
+	if err != nil {
+		return "", err
+	}
+	allPages, err := ports.List(sc, ports.ListOpts{DeviceId: vmId}).AllPages()
+	if err != nil {
+		logrus.Errorf("list vm(%v) ports meet err=%v", vmId, err)
+		return "", err
+	}
+	vmPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return "", err
+	}
+	if len(vmPorts) == 0 {
+		return "", fmt.Errorf("vm(%v) has no nic", vmId)
+	}
+	if nicIp == "" {
+		if len(vmPorts) > 1 {
+			return "", fmt.Errorf("vm(%v) has %v nics, nicIp is needed", vmId, len(vmPorts))
+		}
+		return vmPorts[0].ID, nil
+	}
+	for _, port := range vmPorts {
+		for _, fixedIp := range port.FixedIps {
+			if fixedIp.IpAddress == nicIp {
+				return port.ID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("vm(%v) has no nic with ip(%v)", vmId, nicIp)
+}
+
+type PublicIpBindInputs struct {
+	Inputs []PublicIpBindInput `json:"inputs,omitempty"`
+}
+
+type PublicIpBindInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+
+	// bind to the port directly, or to the nic of the vm
+	PortId string `json:"port_id,omitempty"`
+	VmId   string `json:"vm_id,omitempty"`
+	NicIp  string `json:"nic_ip,omitempty"`
+}
+
+type PublicIpBindOutputs struct {
+	Outputs []PublicIpBindOutput `json:"outputs,omitempty"`
+}
+
+type PublicIpBindOutput struct {
+	CallBackParameter
+	Result
+	Guid   string `json:"guid,omitempty"`
+	Id     string `json:"id,omitempty"`
+	Ip     string `json:"ip,omitempty"`
+	PortId string `json:"port_id,omitempty"`
+}
+
+type PublicIpBindAction struct {
+}
+
+func (action *PublicIpBindAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs PublicIpBindInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkPublicIpBindParam(input PublicIpBindInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Id == "" {
+		return fmt.Errorf("id is empty")
+	}
+	if input.PortId == "" && input.VmId == "" {
+		return fmt.Errorf("portId and vmId are both empty")
+	}
+	return nil
+}
+
+func bindPublicIp(input PublicIpBindInput) (output PublicIpBindOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkPublicIpBindParam(input); err != nil {
+		return
+	}
+	ipInfo, exist, err := isPublicIpExist(input.CloudProviderParam, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("public ip(%v) is not exist", input.Id)
+		return
+	}
+	output.Ip = ipInfo.PublicIpAddress
+
+	portId := input.PortId
+	if portId == "" {
+		if portId, err = getVmPortId(input.CloudProviderParam, input.VmId, input.NicIp); err != nil {
+			return
+		}
+	}
+	output.PortId = portId
+
+	if ipInfo.PortId == portId {
+		logrus.Infof("public ip(%v) is already bound to port(%v)", input.Id, portId)
+		return
+	}
+	if ipInfo.PortId != "" {
+		err = fmt.Errorf("public ip(%v) is bound to another port(%v)", input.Id, ipInfo.PortId)
+		return
+	}
+	err = updatePublicIpPortId(input.CloudProviderParam, input.Id, portId)
+	return
+}
+
+func (action *PublicIpBindAction) Do(inputs interface{}) (interface{}, error) {
+	publicIps, _ := inputs.(PublicIpBindInputs)
+	outputs := PublicIpBindOutputs{}
+	var finalErr error
+
+	for _, input := range publicIps.Inputs {
+		output, err := bindPublicIp(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all publicIp = %v are bound", publicIps)
+	return &outputs, finalErr
+}
+
+//-----------unbind publicIp action---------------//
+type PublicIpUnbindInputs struct {
+	Inputs []PublicIpUnbindInput `json:"inputs,omitempty"`
+}
+
+type PublicIpUnbindInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type PublicIpUnbindOutputs struct {
+	Outputs []PublicIpUnbindOutput `json:"outputs,omitempty"`
+}
+
+type PublicIpUnbindOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type PublicIpUnbindAction struct {
+}
+
+func (action *PublicIpUnbindAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs PublicIpUnbindInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func unbindPublicIp(input PublicIpUnbindInput) (output PublicIpUnbindOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	ipInfo, exist, err := isPublicIpExist(input.CloudProviderParam, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("public ip(%v) is not exist", input.Id)
+		return
+	}
+	if ipInfo.PortId == "" {
+		logrus.Infof("public ip(%v) is not bound", input.Id)
+		return
+	}
+	err = unbindPublicIpPort(input.CloudProviderParam, input.Id)
+	return
+}
+
+func (action *PublicIpUnbindAction) Do(inputs interface{}) (interface{}, error) {
+	publicIps, _ := inputs.(PublicIpUnbindInputs)
+	outputs := PublicIpUnbindOutputs{}
+	var finalErr error
+
+	for _, input := range publicIps.Inputs {
+		output, err := unbindPublicIp(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all publicIp = %v are unbound", publicIps)
+	return &outputs, finalErr
+}
@@ -0,0 +1,174 @@
+package plugins
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/openstack/vpc/v1/bandwidths"
+	"github.com/sirupsen/logrus"
+)
+
+// the sdk update has no charge mode
+type bandwidthUpdateOpts struct {
+	Size       int
+	ChargeMode string
+}
+
+func (opts bandwidthUpdateOpts) ToBandwidthsUpdateMap() (map[string]interface{}, error) {
+	bandwidth := map[string]interface{}{}
+	if opts.Size > 0 {
+		bandwidth["size"] = opts.Size
+	}
+	if opts.ChargeMode != "" {
+		bandwidth["charge_mode"] = opts.ChargeMode
+	}
+	return map[string]interface{}{
+		"bandwidth": bandwidth,
+	}, nil
+}
+
+func updateBandwidth(params CloudProviderParam, bandwidthId string, size int, chargeMode string) error {
+	sc, err := CreateVpcServiceClientV1(params)
+	if err != nil {
+		return err
+	}
+
+	bandwidth, err := bandwidths.Get(sc, bandwidthId).Extract()
+	if err != nil {
+		logrus.Errorf("get bandwidth(%v) meet err=%v", bandwidthId, err)
+		return err
+	}
+	opts := bandwidthUpdateOpts{}
+	if size > 0 && size != bandwidth.Size {
+		opts.Size = size
+	}
+	if chargeMode != "" && chargeMode != bandwidth.ChargeMode {
+		opts.ChargeMode = chargeMode
+	}
+	if opts.Size == 0 && opts.ChargeMode == "" {
+		logrus.Infof("bandwidth(%v) is not changed", bandwidthId)
+		return nil
+	}
+
+	if _, err = bandwidths.Update(sc, bandwidthId, opts).Extract(); err != nil {
+		logrus.Errorf("update bandwidth(%v) meet err=%v", bandwidthId, err)
+	}
+	return err
+}
+
+type PublicIpResizeBandwidthInputs struct {
+	Inputs []PublicIpResizeBandwidthInput `json:"inputs,omitempty"`
+}
+
+type PublicIpResizeBandwidthInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	BandWidth  string `json:"band_width,omitempty"`
+	ChargeMode string `json:"charge_mode,omitempty"`
+}
+
+type PublicIpResizeBandwidthOutputs struct {
+	Outputs []PublicIpResizeBandwidthOutput `json:"outputs,omitempty"`
+}
+
+type PublicIpResizeBandwidthOutput struct {
+	CallBackParameter
+	Result
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	BandwidthId string `json:"bandwidth_id,omitempty"`
+}
+
+type PublicIpResizeBandwidthAction struct {
+}
+
+func (action *PublicIpResizeBandwidthAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs PublicIpResizeBandwidthInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkPublicIpResizeBandwidthParam(input PublicIpResizeBandwidthInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Id == "" {
+		return fmt.Errorf("id is empty")
+	}
+	if input.BandWidth == "" && input.ChargeMode == "" {
+		return fmt.Errorf("bandWidth and chargeMode are both empty")
+	}
+	if input.BandWidth != "" {
+		if _, err := isValidInteger(input.BandWidth, BANDWIDTH_SIZE_MIN, BANDWIDTH_SIZE_MAX); err != nil {
+			return err
+		}
+	}
+	if input.ChargeMode != "" {
+		if err := isValidStringValue("charge_mode", input.ChargeMode, []string{BANDWIDTH_CHARGE_MODE_BANDWIDTH, BANDWIDTH_CHARGE_MODE_TRAFFIC}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resizePublicIpBandwidth(input PublicIpResizeBandwidthInput) (output PublicIpResizeBandwidthOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkPublicIpResizeBandwidthParam(input); err != nil {
+		return
+	}
+	ipInfo, exist, err := isPublicIpExist(input.CloudProviderParam, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("public ip(%v) is not exist", input.Id)
+		return
+	}
+	output.BandwidthId = ipInfo.BandwidthId
+
+	// the shared bandwidth is changed by itself, not by one of its public ips
+	if ipInfo.BandwidthShareType == BANDWIDTH_SHARE_TYPE_WHOLE {
+		err = fmt.Errorf("public ip(%v) uses shared bandwidth(%v)", input.Id, ipInfo.BandwidthId)
+		return
+	}
+
+	size := 0
+	if input.BandWidth != "" {
+		size64, _ := isValidInteger(input.BandWidth, BANDWIDTH_SIZE_MIN, BANDWIDTH_SIZE_MAX)
+		size = int(size64)
+	}
+	err = updateBandwidth(input.CloudProviderParam, ipInfo.BandwidthId, size, input.ChargeMode)
+	return
+}
+
+func (action *PublicIpResizeBandwidthAction) Do(inputs interface{}) (interface{}, error) {
+	publicIps, _ := inputs.(PublicIpResizeBandwidthInputs)
+	outputs := PublicIpResizeBandwidthOutputs{}
+	var finalErr error
+
+	for _, input := range publicIps.Inputs {
+		output, err := resizePublicIpBandwidth(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all publicIp = %v bandwidth are resized", publicIps)
+	return &outputs, finalErr
+}
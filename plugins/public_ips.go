@@ -12,7 +12,8 @@ import (
 )
 
 const (
-	PUBLIC_IP_TYPE_5_BGP       = "5_bgp"
+	PUBLIC_IP_TYPE_5_BGP       = "5_bgp"  //全动态BGP
+	PUBLIC_IP_TYPE_5_SBGP      = "5_sbgp" //静态BGP
 	BANDWIDTH_SHARE_TYPE_PER   = "PER"   //独占带宽
 	BANDWIDTH_SHARE_TYPE_WHOLE = "WHOLE" //共享带宽
 
@@ -21,6 +22,11 @@ const (
 
 	PUBLIC_IP_STATUS_BAD  = "ERROR"
 	PUBLIC_IP_STATUS_GOOD = "DOWN"
+
+	BANDWIDTH_CHARGE_MODE_BANDWIDTH = "bandwidth" //按带宽计费
+	BANDWIDTH_CHARGE_MODE_TRAFFIC   = "traffic"   //按流量计费
+
+	PUBLIC_IP_NOT_FOUND_BY_PORT = "can't found publicIp by portId"
 )
 
 var publicIpActions = make(map[string]Action)
@@ -28,6 +34,9 @@ var publicIpActions = make(map[string]Action)
 func init() {
 	publicIpActions["create"] = new(PublicIpCreateAction)
 	publicIpActions["delete"] = new(PublicIpDeleteAction)
+	publicIpActions["bind"] = new(PublicIpBindAction)
+	publicIpActions["unbind"] = new(PublicIpUnbindAction)
+	publicIpActions["resize-bandwidth"] = new(PublicIpResizeBandwidthAction)
 }
 
 type PublicIpPlugin struct {
@@ -53,6 +62,12 @@ type PublicIpCreateInput struct {
 	Id        string `json:"id,omitempty"`
 	Name      string `json:"name,omitempty"`
 	BandWidth string `json:"band_width,omitempty"`
+
+	IpVersion  string `json:"ip_version,omitempty"`  //4 or 6, the ipv6 eip has both ipv4 and ipv6 address
+	ChargeMode string `json:"charge_mode,omitempty"` //bandwidth or traffic
+
+	PublicIpType string `json:"public_ip_type,omitempty"` //5_bgp or 5_sbgp
+	BandwidthId  string `json:"bandwidth_id,omitempty"`   //join the shared bandwidth instead of a dedicated one
 }
 
 type PublicIpCreateOutputs struct {
@@ -65,6 +80,7 @@ type PublicIpCreateOutput struct {
 	Guid string `json:"guid,omitempty"`
 	Id   string `json:"id,omitempty"`
 	Ip   string `json:"ip,omitempty"`
+	Ipv6 string `json:"ipv6,omitempty"`
 }
 
 type PublicIpCreateAction struct {
@@ -95,21 +111,70 @@ func createPluginPublicIp(input PublicIpCreateInput) (output PublicIpCreateOutpu
 		return
 	}
 	if input.Id != "" {
+		var ipInfo *publicips.PublicIP
 		exist := false
-		_, exist, err = isPublicIpExist(input.CloudProviderParam, input.Id)
+		ipInfo, exist, err = isPublicIpExist(input.CloudProviderParam, input.Id)
 		if err == nil && exist {
 			output.Id = input.Id
+			output.Ip = ipInfo.PublicIpAddress
+			output.Ipv6 = ipInfo.PublicIpV6Address
+			return
+		}
+	}
+	if err = checkPublicIpCreateParam(input); err != nil {
+		return
+	}
+	if input.BandwidthId != "" {
+		var sc *gophercloud.ServiceClient
+		if sc, err = CreateVpcServiceClientV1(input.CloudProviderParam); err != nil {
+			return
+		}
+		exist := false
+		if _, exist, err = getSharedBandwidthById(sc, input.BandwidthId); err != nil {
+			return
+		}
+		if !exist {
+			err = fmt.Errorf("shared bandwidth(%v) is not exist", input.BandwidthId)
 			return
 		}
 	}
 
-	resp, err := createPublicIp(input.CloudProviderParam, input.BandWidth, "", input.Name)
+	name := input.Name
+	if name == "" {
+		name = "wecubeCreated"
+	}
+	ipType := input.PublicIpType
+	if ipType == "" {
+		ipType = PUBLIC_IP_TYPE_5_BGP
+	}
+	size, _ := strconv.Atoi(input.BandWidth)
+	ipVersion, _ := strconv.Atoi(input.IpVersion)
+	bandwidth := publicips.BandWidth{
+		Name:       name,
+		ShareType:  BANDWIDTH_SHARE_TYPE_PER,
+		Size:       size,
+		ChargeMode: input.ChargeMode,
+	}
+	if input.BandwidthId != "" {
+		bandwidth = publicips.BandWidth{
+			ID:        input.BandwidthId,
+			ShareType: BANDWIDTH_SHARE_TYPE_WHOLE,
+		}
+	}
+	resp, err := createPublicIpByOpts(input.CloudProviderParam, publicips.CreateOpts{
+		Publicip: publicips.PublicIPRequest{
+			Type:      ipType,
+			IPVersion: ipVersion,
+		},
+		Bandwidth: bandwidth,
+	})
 	if err != nil {
 		logrus.Errorf("create public ip meet error=%v", err)
 		return
 	}
 	output.Id = resp.ID
 	output.Ip = resp.PublicIpAddress
+	output.Ipv6 = resp.PublicIpV6Address
 
 	err = waitPublicIpJobOk(input.CloudProviderParam, output.Id, "create", 10)
 	if err != nil {
@@ -118,6 +183,31 @@ func createPluginPublicIp(input PublicIpCreateInput) (output PublicIpCreateOutpu
 	return
 }
 
+func checkPublicIpCreateParam(input PublicIpCreateInput) error {
+	if input.IpVersion != "" {
+		if err := isValidStringValue("ip_version", input.IpVersion, []string{"4", "6"}); err != nil {
+			return err
+		}
+	}
+	if input.ChargeMode != "" {
+		if err := isValidStringValue("charge_mode", input.ChargeMode, []string{BANDWIDTH_CHARGE_MODE_BANDWIDTH, BANDWIDTH_CHARGE_MODE_TRAFFIC}); err != nil {
+			return err
+		}
+	}
+	if input.PublicIpType != "" {
+		if err := isValidStringValue("public_ip_type", input.PublicIpType, []string{PUBLIC_IP_TYPE_5_BGP, PUBLIC_IP_TYPE_5_SBGP}); err != nil {
+			return err
+		}
+	}
+	if input.BandwidthId != "" {
+		return nil
+	}
+	if _, err := isValidInteger(input.BandWidth, BANDWIDTH_SIZE_MIN, BANDWIDTH_SIZE_MAX); err != nil {
+		return fmt.Errorf("bandWidth is invalid, %v", err)
+	}
+	return nil
+}
+
 func (action *PublicIpCreateAction) Do(inputs interface{}) (interface{}, error) {
 	publicIps, _ := inputs.(PublicIpCreateInputs)
 	outputs := PublicIpCreateOutputs{}
@@ -249,17 +339,12 @@ func getPublicIpInfo(params CloudProviderParam, id string) (*publicips.PublicIP,
 }
 
 func createPublicIp(params CloudProviderParam, bandwidthSize string, enterpriseProjectId string, name string) (*publicips.PublicIPCreateResp, error) {
-	sc, err := CreateVpcServiceClientV1(params)
-	if err != nil {
-		return nil, err
-	}
-
 	if name == "" {
 		name = "wecubeCreated"
 	}
 
 	size, _ := strconv.Atoi(bandwidthSize)
-	resp, err := publicips.Create(sc, publicips.CreateOpts{
+	return createPublicIpByOpts(params, publicips.CreateOpts{
 		Publicip: publicips.PublicIPRequest{
 			Type:      PUBLIC_IP_TYPE_5_BGP,
 			IPVersion: 4,
@@ -270,7 +355,16 @@ func createPublicIp(params CloudProviderParam, bandwidthSize string, enterpriseP
 			Size:      size,
 		},
 		EnterpriseProjectId: enterpriseProjectId,
-	}).Extract()
+	})
+}
+
+func createPublicIpByOpts(params CloudProviderParam, opts publicips.CreateOpts) (*publicips.PublicIPCreateResp, error) {
+	sc, err := CreateVpcServiceClientV1(params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := publicips.Create(sc, opts).Extract()
 	if err != nil {
 		logrus.Errorf("createPublicIp meet err=%v", err)
 	}
@@ -294,6 +388,25 @@ func updatePublicIpPortId(params CloudProviderParam, lbId string, portId string)
 	return err
 }
 
+// the sdk update omits the empty port id, so put the null port id directly
+func unbindPublicIpPort(params CloudProviderParam, id string) error {
+	sc, err := CreateVpcServiceClientV1(params)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"publicip": map[string]interface{}{
+			"port_id": nil,
+		},
+	}
+	_, err = sc.Put(publicips.UpdateURL(sc, id), body, nil, &gophercloud.RequestOpts{OkCodes: []int{200}})
+	if err != nil {
+		logrus.Errorf("unbindPublicIpPort meet err=%v", err)
+	}
+	return err
+}
+
 func deletePublicIp(params CloudProviderParam, id string) error {
 	sc, err := CreateVpcServiceClientV1(params)
 	if err != nil {
@@ -368,5 +481,9 @@ func getPublicIpByPortId(params CloudProviderParam, portId string) (*publicips.P
 			return &resp, nil
 		}
 	}
-	return nil, fmt.Errorf("can't found publicIp by portId(%v)", portId)
+	return nil, fmt.Errorf("%s(%v)", PUBLIC_IP_NOT_FOUND_BY_PORT, portId)
+}
+
+func isPublicIpNotFoundByPortErr(err error) bool {
+	return strings.Contains(err.Error(), PUBLIC_IP_NOT_FOUND_BY_PORT)
 }
@@ -36,6 +36,12 @@ const (
 	RDS_BACKUP_STATUS_OK       = "COMPLETED"
 	RDS_BACKUP_STATUS_BAD      = "FAILED"
 	RDS_FLAVORREF_AZ_STATUS_OK = "normal"
+
+	RDS_INSTANCE_MODE_SINGLE  = "single"
+	RDS_INSTANCE_MODE_HA      = "ha"
+	RDS_INSTANCE_MODE_REPLICA = "replica"
+
+	RDS_INSTANCE_TYPE_REPLICA = "Replica"
 )
 
 var rdsActions = make(map[string]Action)
@@ -45,11 +51,31 @@ var rdsEngineTypeMap = map[string]string{
 	"SQLSERVER":  "SQLServer",
 }
 
+var rdsEngineDefaultPortMap = map[string]string{
+	"MySQL":      "3306",
+	"PostgreSQL": "5432",
+	"SQLServer":  "1433",
+}
+
+var rdsEngineHaReplicationModeMap = map[string][]string{
+	"MySQL":      []string{"async", "semisync"},
+	"PostgreSQL": []string{"async", "sync"},
+	"SQLServer":  []string{"sync"},
+}
+
 func init() {
 	rdsActions["create"] = new(RdsCreateAction)
 	rdsActions["delete"] = new(RdsDeleteAction)
 	rdsActions["create-backup"] = new(RdsCreateBackupAction)
 	rdsActions["delete-backup"] = new(RdsDeleteBackupAction)
+	rdsActions["create-read-replica"] = new(RdsCreateReadReplicaAction)
+	rdsActions["delete-read-replica"] = new(RdsDeleteReadReplicaAction)
+	rdsActions["promote"] = new(RdsPromoteAction)
+	rdsActions["resize-flavor"] = new(RdsResizeFlavorAction)
+	rdsActions["expand-volume"] = new(RdsExpandVolumeAction)
+	rdsActions["restore"] = new(RdsRestoreAction)
+	rdsActions["apply-parameter-group"] = new(RdsApplyParameterGroupAction)
+	rdsActions["update-policy"] = new(RdsUpdatePolicyAction)
 }
 
 func createRdsServiceClientV3(params CloudProviderParam) (*gophercloud.ServiceClient, error) {
@@ -106,6 +132,7 @@ type RdsCreateInputs struct {
 type RdsCreateInput struct {
 	CallBackParameter
 	CloudProviderParam
+	RdsPolicyParam
 	Guid              string `json:"guid,omitempty"`
 	Id                string `json:"id,omitempty"`
 	Seed              string `json:"seed,omitempty"`
@@ -133,8 +160,9 @@ type RdsCreateInput struct {
 	EnterpriseProjectId string `json:"enterprise_project_id,omitempty"`
 	CharacterSet        string `json:"character_set,omitempty"`
 	LowerCaseTableNames string `json:"lower_case_table_names,omitempty"`
-	// EngineType       string `json:"engine_type,omitempty"`
-
+	EngineType          string `json:"engine_type,omitempty"`
+	Parameters          string `json:"parameters,omitempty"` //key1=value1;key2=value2
+	ParameterGroupId    string `json:"parameter_group_id,omitempty"`
 }
 
 type RdsCreateOutputs struct {
@@ -204,9 +232,15 @@ func (action *RdsCreateAction) checkCreateRdsParams(input RdsCreateInput) error
 		return fmt.Errorf("volumeType is wrong")
 	}
 
-	if err := checkEngineParams(input.CloudProviderParam, rdsEngineTypeMap["MYSQL"], input.EngineVersion); err != nil {
+	engineType := getRdsEngineType(&input)
+	if err := checkEngineParams(input.CloudProviderParam, engineType, input.EngineVersion); err != nil {
 		return err
 	}
+	if input.Port != "" {
+		if err := isValidPort(input.Port); err != nil {
+			return err
+		}
+	}
 	if input.AvailabilityZone == "" {
 		return fmt.Errorf("availabilityZone is empty")
 	}
@@ -237,22 +271,68 @@ func (action *RdsCreateAction) checkCreateRdsParams(input RdsCreateInput) error
 		if strings.ToLower(input.SupportHa) != "true" && strings.ToLower(input.SupportHa) != "false" {
 			return fmt.Errorf("supportHa is wrong")
 		}
-		if strings.ToLower(input.SupportHa) == "true" && input.HaReplicationMode == "" {
-			return fmt.Errorf("haReplicationMode is empty")
+		if strings.ToLower(input.SupportHa) == "true" {
+			if err := isValidStringValue("haReplicationMode", input.HaReplicationMode, rdsEngineHaReplicationModeMap[engineType]); err != nil {
+				return err
+			}
 		}
 	}
 
-	if err := isVaildCharset(strings.ToLower(input.CharacterSet)); err != nil {
+	if err := checkRdsConfigurationParams(&input, engineType); err != nil {
 		return err
 	}
 
-	if err := isValidLowerCaseTableNames(input.LowerCaseTableNames); err != nil {
+	if err := checkRdsPolicyParams(input.RdsPolicyParam, engineType); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// the empty values are left to the parameter group or the defaults of buildRdsConfigurationValues
+func checkRdsConfigurationParams(input *RdsCreateInput, engineType string) error {
+	// character_set_server and lower_case_table_names are only for mysql
+	if engineType == rdsEngineTypeMap["MYSQL"] {
+		if input.CharacterSet != "" {
+			if err := isVaildCharset(strings.ToLower(input.CharacterSet)); err != nil {
+				return err
+			}
+		}
+		if input.LowerCaseTableNames != "" {
+			if err := isValidLowerCaseTableNames(input.LowerCaseTableNames); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := getRdsParametersFromString(input.Parameters); err != nil {
+		return err
+	}
+	return nil
+}
+
+// engine type is MySQL by default
+func getRdsEngineType(input *RdsCreateInput) string {
+	if input.EngineType == "" {
+		return rdsEngineTypeMap["MYSQL"]
+	}
+	if engineType, ok := rdsEngineTypeMap[strings.ToUpper(input.EngineType)]; ok {
+		return engineType
+	}
+	return input.EngineType
+}
+
+func getRdsParametersFromString(parameters string) (map[string]string, error) {
+	if strings.TrimSpace(parameters) == "" {
+		return map[string]string{}, nil
+	}
+	values, err := GetMapFromString(parameters)
+	if err != nil {
+		return nil, fmt.Errorf("parameters(%v) is invalid", parameters)
+	}
+	return values, nil
+}
+
 func isValidLowerCaseTableNames(value string) error {
 	if value != "1" && value != "0" {
 		return fmt.Errorf("lowerCaseTableNames(%v) is invalid", value)
@@ -280,7 +360,8 @@ func checkEngineParams(params CloudProviderParam, engineType, engineVersion stri
 	if engineVersion == "" {
 		return fmt.Errorf("engineVersion is empty")
 	}
-	if _, ok := rdsEngineTypeMap[strings.ToUpper(engineType)]; !ok {
+	engineType, ok := rdsEngineTypeMap[strings.ToUpper(engineType)]
+	if !ok {
 		return fmt.Errorf("engineType is wrong")
 	}
 	versionMap, err := queryEngineVersionInfo(params, engineType)
@@ -288,7 +369,11 @@ func checkEngineParams(params CloudProviderParam, engineType, engineVersion stri
 		return err
 	}
 	if _, ok := versionMap[engineVersion]; !ok {
-		return fmt.Errorf("engineVersion is wrong")
+		supportVersions := []string{}
+		for version := range versionMap {
+			supportVersions = append(supportVersions, version)
+		}
+		return fmt.Errorf("engineVersion(%v) is wrong, %v supports %v", engineVersion, engineType, supportVersions)
 	}
 
 	return nil
@@ -329,8 +414,15 @@ func getAzs(input *RdsCreateInput) ([]string, error) {
 	return azs, nil
 }
 
+func getRdsInstanceMode(input *RdsCreateInput) string {
+	if strings.ToLower(input.SupportHa) == "true" {
+		return RDS_INSTANCE_MODE_HA
+	}
+	return RDS_INSTANCE_MODE_SINGLE
+}
+
 // return flavor name, cpu, ram
-func getRdsFlavorByHostType(input *RdsCreateInput, azs []string) (string, string, string, error) {
+func getRdsFlavorByHostType(input *RdsCreateInput, azs []string, instanceMode string) (string, string, string, error) {
 	cpu, memory, err := getCpuAndMemoryFromHostType(input.HostType)
 	if err != nil {
 		return "", "", "", err
@@ -342,7 +434,7 @@ func getRdsFlavorByHostType(input *RdsCreateInput, azs []string) (string, string
 	}
 	allPages, err := flavors.List(sc, flavors.DbFlavorsOpts{
 		Versionname: input.EngineVersion,
-	}, rdsEngineTypeMap["MYSQL"]).AllPages()
+	}, getRdsEngineType(input)).AllPages()
 	if err != nil {
 		return "", "", "", err
 	}
@@ -370,16 +462,12 @@ func getRdsFlavorByHostType(input *RdsCreateInput, azs []string) (string, string
 			continue
 		}
 
-		if strings.ToLower(input.SupportHa) == "true" && item.Instancemode != "ha" {
-			continue
-		}
-
-		if (strings.ToLower(input.SupportHa) == "false" || input.SupportHa == "") && item.Instancemode != "single" {
+		if item.Instancemode != instanceMode {
 			continue
 		}
 
 		// get flavorRef type
-		flavorType := strings.Split(item.Speccode, ".")[2]
+		flavorType := getRdsFlavorTypeFromSpecCode(item.Speccode)
 		if input.FlavorType != "" {
 			if strings.Compare(strings.ToLower(input.FlavorType), flavorType) != 0 {
 				continue
@@ -424,6 +512,18 @@ func getRdsFlavorByHostType(input *RdsCreateInput, azs []string) (string, string
 	return flavorRef, newCpu, ram, nil
 }
 
+// spec code is like rds.mysql.c2.large.ha or rds.mssql.se.s3.large.2.ha
+func getRdsFlavorTypeFromSpecCode(specCode string) string {
+	fields := strings.Split(specCode, ".")
+	if len(fields) < 3 {
+		return ""
+	}
+	if fields[1] == "mssql" && len(fields) > 3 {
+		return fields[3]
+	}
+	return fields[2]
+}
+
 func buildRdsVolumeStruct(input *RdsCreateInput) (*instances.Volume, error) {
 	if input.VolumeType == "" {
 		input.VolumeType = "ULTRAHIGH"
@@ -467,23 +567,42 @@ func buildChargeInfoStruct(input *RdsCreateInput) *instances.ChargeInfo {
 	return &chargeInfo
 }
 
-func updateRdsConfiguration(input *RdsCreateInput, rdsId string) (bool, error) {
-	sc, err := createGolangSdkRdsServiceClientV3(input.CloudProviderParam)
-	if err != nil {
-		return false, err
+// engine specific parameters, the user defined parameters will overwrite the default ones
+func buildRdsConfigurationValues(input *RdsCreateInput) map[string]string {
+	value := map[string]string{}
+	// the defaults would override the chosen parameter group, so only the explicit values are sent
+	useDefault := input.ParameterGroupId == ""
+	switch getRdsEngineType(input) {
+	case rdsEngineTypeMap["MYSQL"]:
+		if input.CharacterSet != "" {
+			value["character_set_server"] = strings.ToLower(input.CharacterSet)
+		} else if useDefault {
+			value["character_set_server"] = "utf8"
+		}
+
+		if input.LowerCaseTableNames == "0" {
+			value["lower_case_table_names"] = "0"
+		} else if input.LowerCaseTableNames != "" || useDefault {
+			value["lower_case_table_names"] = "1"
+		}
 	}
 
-	value := map[string]string{}
-	if input.CharacterSet != "" {
-		value["character_set_server"] = strings.ToLower(input.CharacterSet)
-	} else {
-		value["character_set_server"] = "utf8"
+	parameters, _ := getRdsParametersFromString(input.Parameters)
+	for key, val := range parameters {
+		value[key] = val
 	}
+	return value
+}
 
-	if input.LowerCaseTableNames == "0" {
-		value["lower_case_table_names"] = "0"
-	} else {
-		value["lower_case_table_names"] = "1"
+func updateRdsConfiguration(input *RdsCreateInput, rdsId string) (bool, error) {
+	value := buildRdsConfigurationValues(input)
+	if len(value) == 0 {
+		return false, nil
+	}
+
+	sc, err := createGolangSdkRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return false, err
 	}
 
 	updateOpts := goInstances.UpdateInstanceConfigOpts{
@@ -491,9 +610,13 @@ func updateRdsConfiguration(input *RdsCreateInput, rdsId string) (bool, error) {
 	}
 	logrus.Infof("updateOpts:%++v", updateOpts)
 	resp, err := goInstances.UpdateInstanceConfig(sc, rdsId, updateOpts).Extract()
+	if err != nil {
+		logrus.Errorf("UpdateInstanceConfig meet err=%v", err)
+		return false, err
+	}
 	logrus.Infof("UpdateInstanceConfig resp=%++v", resp)
 
-	return resp.NeedRestartInstance, err
+	return resp.NeedRestartInstance, nil
 }
 
 func restartRds(sc *gophercloud.ServiceClient, id string) error {
@@ -515,25 +638,12 @@ func createRdsConfiguration(input *RdsCreateInput) (string, error) {
 		return "", err
 	}
 
-	value := map[string]string{}
-	if input.CharacterSet != "" {
-		value["character_set_server"] = strings.ToLower(input.CharacterSet)
-	} else {
-		value["character_set_server"] = "utf8"
-	}
-
-	if input.LowerCaseTableNames == "0" {
-		value["lower_case_table_names"] = "0"
-	} else {
-		value["lower_case_table_names"] = "1"
-	}
-
 	createOpts := configurations.CreateConfigurationsOpts{
 		Name:        input.Name + "_configuration",
 		Description: "the configuration of " + input.Name,
-		Values:      value,
+		Values:      buildRdsConfigurationValues(input),
 		Datastore: &configurations.Datastore{
-			Type:    rdsEngineTypeMap["MYSQL"],
+			Type:    getRdsEngineType(input),
 			Version: input.EngineVersion,
 		},
 	}
@@ -609,7 +719,7 @@ func (action *RdsCreateAction) createRds(input *RdsCreateInput) (output RdsCreat
 	if input.Password == "" {
 		input.Password = utils.CreateRandomPassword()
 	}
-	flavor, cpu, memory, err := getRdsFlavorByHostType(input, azs)
+	flavor, cpu, memory, err := getRdsFlavorByHostType(input, azs, getRdsInstanceMode(input))
 	if err != nil {
 		return
 	}
@@ -619,7 +729,7 @@ func (action *RdsCreateAction) createRds(input *RdsCreateInput) (output RdsCreat
 	}
 
 	datastore := instances.Datastore{
-		Type:    rdsEngineTypeMap["MYSQL"],
+		Type:    getRdsEngineType(input),
 		Version: input.EngineVersion,
 	}
 
@@ -647,13 +757,30 @@ func (action *RdsCreateAction) createRds(input *RdsCreateInput) (output RdsCreat
 		ChargeInfo:       buildChargeInfoStruct(input),
 		Ha:               ha,
 		// ConfigurationId:  configurationId,
+		ConfigurationId: input.ParameterGroupId,
 	}
 	if input.Port != "" {
 		request.Port = input.Port
+	} else {
+		request.Port = rdsEngineDefaultPortMap[datastore.Type]
 	}
 	if input.EnterpriseProjectId != "" {
 		request.EnterpriseProjectId = input.EnterpriseProjectId
 	}
+	policyParam := input.RdsPolicyParam
+	if input.BackupStartTime != "" {
+		request.BackupStrategy = &instances.BackupStrategy{
+			StartTime: input.BackupStartTime,
+		}
+		if input.BackupKeepDays != "" {
+			request.BackupStrategy.KeepDays, _ = strconv.Atoi(input.BackupKeepDays)
+		}
+		// backup period can only be set after created
+		if input.BackupPeriod == "" {
+			policyParam.BackupKeepDays = ""
+			policyParam.BackupStartTime = ""
+		}
+	}
 
 	logrus.Infof("request=%++v", request)
 	response, err := instances.Create(sc, request).Extract()
@@ -661,6 +788,12 @@ func (action *RdsCreateAction) createRds(input *RdsCreateInput) (output RdsCreat
 		return
 	}
 	output.Id = response.Instance.Id
+
+	// return the password as soon as the instance is created, the later updates may fail
+	if output.Password, err = utils.AesEnPassword(input.Guid, input.Seed, input.Password, utils.DEFALT_CIPHER); err != nil {
+		return
+	}
+
 	instance, err := waitRdsInstanceJobOk(sc, response.Instance.Id, "create", 20)
 	if err != nil {
 		return
@@ -683,14 +816,13 @@ func (action *RdsCreateAction) createRds(input *RdsCreateInput) (output RdsCreat
 		}
 	}
 
-	password, err := utils.AesEnPassword(input.Guid, input.Seed, input.Password, utils.DEFALT_CIPHER)
-	if err != nil {
-		return
+	if !isRdsPolicyParamEmpty(policyParam) {
+		if err = updateRdsPolicy(sc, output.Id, policyParam); err != nil {
+			return
+		}
 	}
-	output.Password = password
 
 	return
-
 }
 
 func (action *RdsCreateAction) Do(inputs interface{}) (interface{}, error) {
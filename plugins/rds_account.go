@@ -0,0 +1,574 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WeBankPartners/wecube-plugins-huaweicloud/plugins/utils"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/db_privilege"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/db_user"
+	"github.com/sirupsen/logrus"
+)
+
+var rdsAccountActions = make(map[string]Action)
+
+func init() {
+	rdsAccountActions["create"] = new(RdsAccountCreateAction)
+	rdsAccountActions["delete"] = new(RdsAccountDeleteAction)
+	rdsAccountActions["reset-password"] = new(RdsAccountResetPasswordAction)
+	rdsAccountActions["grant"] = new(RdsAccountGrantAction)
+	rdsAccountActions["revoke"] = new(RdsAccountRevokeAction)
+}
+
+type RdsAccountPlugin struct {
+}
+
+func (plugin *RdsAccountPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := rdsAccountActions[actionName]
+	if !found {
+		logrus.Errorf("rds-account plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("rds-account plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func isRdsAccountExist(sc *gophercloud.ServiceClient, instanceId string, name string) (bool, error) {
+	total := 0
+	for page := 1; ; page++ {
+		allPages, err := db_user.List(sc, db_user.ListDbUsersOpts{Page: page, Limit: RDS_LIST_PAGE_LIMIT}, instanceId).AllPages()
+		if err != nil {
+			logrus.Errorf("list rds[%v] accounts meet err=%v", instanceId, err)
+			return false, err
+		}
+		resp, err := db_user.ExtractDbUsers(allPages)
+		if err != nil {
+			return false, err
+		}
+		for _, user := range resp.UsersList {
+			if user.Name == name {
+				return true, nil
+			}
+		}
+		total += len(resp.UsersList)
+		if len(resp.UsersList) == 0 || total >= resp.Totalcount {
+			break
+		}
+	}
+	return false, nil
+}
+
+func waitRdsAccountJobOk(sc *gophercloud.ServiceClient, instanceId string, name string, action string) error {
+	count := 0
+	for {
+		exist, err := isRdsAccountExist(sc, instanceId, name)
+		if err != nil {
+			return err
+		}
+		if action == "create" && exist {
+			return nil
+		}
+		if action == "delete" && !exist {
+			return nil
+		}
+
+		count++
+		if count > 20 {
+			break
+		}
+		time.Sleep(3 * time.Second)
+	}
+	return fmt.Errorf("%v rds account(%v) timeout", action, name)
+}
+
+func grantRdsAccount(sc *gophercloud.ServiceClient, instanceId string, name string, databases []string, readonly bool) error {
+	for _, db := range databases {
+		opts := db_privilege.DbprivilegeOpts{
+			Dbname: db,
+			Users: []db_privilege.User{
+				{Name: name, Readonly: readonly},
+			},
+		}
+		if _, err := db_privilege.Create(sc, opts, instanceId).Extract(); err != nil {
+			logrus.Errorf("grant rds[%v] database(%v) to account(%v) meet err=%v", instanceId, db, name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func revokeRdsAccount(sc *gophercloud.ServiceClient, instanceId string, name string, databases []string) error {
+	for _, db := range databases {
+		opts := db_privilege.DeleteDbprivilegeOpts{
+			Dbname: db,
+			Users: []db_privilege.DeleteUsers{
+				{Name: name},
+			},
+		}
+		if _, err := db_privilege.Delete(sc, opts, instanceId).Extract(); err != nil {
+			logrus.Errorf("revoke rds[%v] database(%v) from account(%v) meet err=%v", instanceId, db, name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+type RdsAccountCreateInputs struct {
+	Inputs []RdsAccountCreateInput `json:"inputs,omitempty"`
+}
+
+type RdsAccountCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	Seed       string `json:"seed,omitempty"`
+	InstanceId string `json:"instance_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Password   string `json:"password,omitempty"`
+	Databases  string `json:"databases,omitempty"`
+	Readonly   string `json:"readonly,omitempty"`
+}
+
+type RdsAccountCreateOutputs struct {
+	Outputs []RdsAccountCreateOutput `json:"outputs,omitempty"`
+}
+
+type RdsAccountCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid     string `json:"guid,omitempty"`
+	Id       string `json:"id,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+type RdsAccountCreateAction struct {
+}
+
+func (action *RdsAccountCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsAccountCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkRdsAccountCreateParams(input RdsAccountCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Seed == "" {
+		return fmt.Errorf("seed is empty")
+	}
+	if input.InstanceId == "" {
+		return fmt.Errorf("instance_id is empty")
+	}
+	if input.Name == "" {
+		return fmt.Errorf("name is empty")
+	}
+	if input.Readonly != "" && strings.ToLower(input.Readonly) != "true" && strings.ToLower(input.Readonly) != "false" {
+		return fmt.Errorf("readonly is wrong")
+	}
+	return nil
+}
+
+func createRdsAccount(input RdsAccountCreateInput) (output RdsAccountCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkRdsAccountCreateParams(input); err != nil {
+		return
+	}
+	databases, err := GetArrayFromString(input.Databases, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	if input.Id != "" {
+		exist := false
+		exist, err = isRdsAccountExist(sc, input.InstanceId, input.Id)
+		if err == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	if input.Password == "" {
+		input.Password = utils.CreateRandomPassword()
+	}
+	opts := db_user.CreateDbUserOpts{
+		Username: input.Name,
+		Password: input.Password,
+	}
+	if _, err = db_user.Create(sc, opts, input.InstanceId).Extract(); err != nil {
+		logrus.Errorf("create rds[%v] account(%v) meet err=%v", input.InstanceId, input.Name, err)
+		return
+	}
+	if err = waitRdsAccountJobOk(sc, input.InstanceId, input.Name, "create"); err != nil {
+		return
+	}
+	output.Id = input.Name
+
+	// return the password even if the grant fails, the account is already created with it
+	if output.Password, err = utils.AesEnPassword(input.Guid, input.Seed, input.Password, utils.DEFALT_CIPHER); err != nil {
+		return
+	}
+	err = grantRdsAccount(sc, input.InstanceId, input.Name, databases, strings.ToLower(input.Readonly) == "true")
+	return
+}
+
+func (action *RdsAccountCreateAction) Do(inputs interface{}) (interface{}, error) {
+	accounts, _ := inputs.(RdsAccountCreateInputs)
+	outputs := RdsAccountCreateOutputs{}
+	var finalErr error
+
+	for _, input := range accounts.Inputs {
+		output, err := createRdsAccount(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete rds account------------------//
+type RdsAccountDeleteInputs struct {
+	Inputs []RdsAccountDeleteInput `json:"inputs,omitempty"`
+}
+
+type RdsAccountDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	InstanceId string `json:"instance_id,omitempty"`
+}
+
+type RdsAccountDeleteOutputs struct {
+	Outputs []RdsAccountDeleteOutput `json:"outputs,omitempty"`
+}
+
+type RdsAccountDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type RdsAccountDeleteAction struct {
+}
+
+func (action *RdsAccountDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsAccountDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkRdsAccountParams(cloudProviderParam CloudProviderParam, instanceId string, id string) error {
+	if err := isCloudProviderParamValid(cloudProviderParam); err != nil {
+		return err
+	}
+	if instanceId == "" {
+		return fmt.Errorf("instance_id is empty")
+	}
+	if id == "" {
+		return fmt.Errorf("id is empty")
+	}
+	return nil
+}
+
+func deleteRdsAccount(input RdsAccountDeleteInput) (output RdsAccountDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkRdsAccountParams(input.CloudProviderParam, input.InstanceId, input.Id); err != nil {
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	exist, err := isRdsAccountExist(sc, input.InstanceId, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	if _, err = db_user.Delete(sc, input.InstanceId, input.Id).Extract(); err != nil {
+		logrus.Errorf("delete rds[%v] account(%v) meet err=%v", input.InstanceId, input.Id, err)
+		return
+	}
+	err = waitRdsAccountJobOk(sc, input.InstanceId, input.Id, "delete")
+	return
+}
+
+func (action *RdsAccountDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	accounts, _ := inputs.(RdsAccountDeleteInputs)
+	outputs := RdsAccountDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range accounts.Inputs {
+		output, err := deleteRdsAccount(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------reset rds account password------------------//
+type RdsAccountResetPasswordInputs struct {
+	Inputs []RdsAccountResetPasswordInput `json:"inputs,omitempty"`
+}
+
+type RdsAccountResetPasswordInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	Seed       string `json:"seed,omitempty"`
+	InstanceId string `json:"instance_id,omitempty"`
+	Password   string `json:"password,omitempty"`
+}
+
+type RdsAccountResetPasswordAction struct {
+}
+
+func (action *RdsAccountResetPasswordAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsAccountResetPasswordInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func resetRdsAccountPassword(input RdsAccountResetPasswordInput) (output RdsAccountCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkRdsAccountParams(input.CloudProviderParam, input.InstanceId, input.Id); err != nil {
+		return
+	}
+	if input.Seed == "" {
+		err = fmt.Errorf("seed is empty")
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	exist, err := isRdsAccountExist(sc, input.InstanceId, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("rds[%v] account(%v) is not exist", input.InstanceId, input.Id)
+		return
+	}
+
+	if input.Password == "" {
+		input.Password = utils.CreateRandomPassword()
+	}
+	body := map[string]interface{}{
+		"name":     input.Id,
+		"password": input.Password,
+	}
+	url := sc.ServiceURL("instances", input.InstanceId, "db_user", "resetpwd")
+	if _, err = sc.Post(url, body, nil, &gophercloud.RequestOpts{OkCodes: []int{200, 202}}); err != nil {
+		logrus.Errorf("reset rds[%v] account(%v) password meet err=%v", input.InstanceId, input.Id, err)
+		return
+	}
+
+	output.Password, err = utils.AesEnPassword(input.Guid, input.Seed, input.Password, utils.DEFALT_CIPHER)
+	return
+}
+
+func (action *RdsAccountResetPasswordAction) Do(inputs interface{}) (interface{}, error) {
+	accounts, _ := inputs.(RdsAccountResetPasswordInputs)
+	outputs := RdsAccountCreateOutputs{}
+	var finalErr error
+
+	for _, input := range accounts.Inputs {
+		output, err := resetRdsAccountPassword(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------grant/revoke rds account privilege------------------//
+type RdsAccountPrivilegeInputs struct {
+	Inputs []RdsAccountPrivilegeInput `json:"inputs,omitempty"`
+}
+
+type RdsAccountPrivilegeInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	InstanceId string `json:"instance_id,omitempty"`
+	Databases  string `json:"databases,omitempty"`
+	Readonly   string `json:"readonly,omitempty"`
+}
+
+type RdsAccountPrivilegeOutputs struct {
+	Outputs []RdsAccountPrivilegeOutput `json:"outputs,omitempty"`
+}
+
+type RdsAccountPrivilegeOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+func checkRdsAccountPrivilegeParams(input RdsAccountPrivilegeInput) ([]string, error) {
+	if err := checkRdsAccountParams(input.CloudProviderParam, input.InstanceId, input.Id); err != nil {
+		return nil, err
+	}
+	if input.Readonly != "" && strings.ToLower(input.Readonly) != "true" && strings.ToLower(input.Readonly) != "false" {
+		return nil, fmt.Errorf("readonly is wrong")
+	}
+	databases, err := GetArrayFromString(input.Databases, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(databases) == 0 {
+		return nil, fmt.Errorf("databases is empty")
+	}
+	return databases, nil
+}
+
+func updateRdsAccountPrivilege(input RdsAccountPrivilegeInput, isGrant bool) (output RdsAccountPrivilegeOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	databases, err := checkRdsAccountPrivilegeParams(input)
+	if err != nil {
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	if isGrant {
+		err = grantRdsAccount(sc, input.InstanceId, input.Id, databases, strings.ToLower(input.Readonly) == "true")
+	} else {
+		err = revokeRdsAccount(sc, input.InstanceId, input.Id, databases)
+	}
+	return
+}
+
+type RdsAccountGrantAction struct {
+}
+
+func (action *RdsAccountGrantAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsAccountPrivilegeInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *RdsAccountGrantAction) Do(inputs interface{}) (interface{}, error) {
+	accounts, _ := inputs.(RdsAccountPrivilegeInputs)
+	outputs := RdsAccountPrivilegeOutputs{}
+	var finalErr error
+
+	for _, input := range accounts.Inputs {
+		output, err := updateRdsAccountPrivilege(input, true)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+type RdsAccountRevokeAction struct {
+}
+
+func (action *RdsAccountRevokeAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsAccountPrivilegeInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *RdsAccountRevokeAction) Do(inputs interface{}) (interface{}, error) {
+	accounts, _ := inputs.(RdsAccountPrivilegeInputs)
+	outputs := RdsAccountPrivilegeOutputs{}
+	var finalErr error
+
+	for _, input := range accounts.Inputs {
+		output, err := updateRdsAccountPrivilege(input, false)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
@@ -0,0 +1,309 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/database"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RDS_LIST_PAGE_LIMIT = 100
+)
+
+var rdsDatabaseActions = make(map[string]Action)
+
+func init() {
+	rdsDatabaseActions["create"] = new(RdsDatabaseCreateAction)
+	rdsDatabaseActions["delete"] = new(RdsDatabaseDeleteAction)
+}
+
+type RdsDatabasePlugin struct {
+}
+
+func (plugin *RdsDatabasePlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := rdsDatabaseActions[actionName]
+	if !found {
+		logrus.Errorf("rds-database plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("rds-database plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func listRdsDatabases(sc *gophercloud.ServiceClient, instanceId string) ([]database.Databases, error) {
+	databases := []database.Databases{}
+	for page := 1; ; page++ {
+		allPages, err := database.List(sc, database.ListOpts{Page: page, Limit: RDS_LIST_PAGE_LIMIT}, instanceId).AllPages()
+		if err != nil {
+			logrus.Errorf("list rds[%v] databases meet err=%v", instanceId, err)
+			return databases, err
+		}
+		resp, err := database.ExtractDataBase(allPages)
+		if err != nil {
+			return databases, err
+		}
+		databases = append(databases, resp.DatabasesList...)
+		if len(resp.DatabasesList) == 0 || len(databases) >= resp.Totalcount {
+			break
+		}
+	}
+	return databases, nil
+}
+
+func getRdsDatabase(sc *gophercloud.ServiceClient, instanceId string, name string) (*database.Databases, error) {
+	databases, err := listRdsDatabases(sc, instanceId)
+	if err != nil {
+		return nil, err
+	}
+	for i, db := range databases {
+		if db.Name == name {
+			return &databases[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func waitRdsDatabaseJobOk(sc *gophercloud.ServiceClient, instanceId string, name string, action string) error {
+	count := 0
+	for {
+		db, err := getRdsDatabase(sc, instanceId, name)
+		if err != nil {
+			return err
+		}
+		if action == "create" && db != nil {
+			return nil
+		}
+		if action == "delete" && db == nil {
+			return nil
+		}
+
+		count++
+		if count > 20 {
+			break
+		}
+		time.Sleep(3 * time.Second)
+	}
+	return fmt.Errorf("%v rds database(%v) timeout", action, name)
+}
+
+type RdsDatabaseCreateInputs struct {
+	Inputs []RdsDatabaseCreateInput `json:"inputs,omitempty"`
+}
+
+type RdsDatabaseCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid         string `json:"guid,omitempty"`
+	Id           string `json:"id,omitempty"`
+	InstanceId   string `json:"instance_id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	CharacterSet string `json:"character_set,omitempty"`
+}
+
+type RdsDatabaseCreateOutputs struct {
+	Outputs []RdsDatabaseCreateOutput `json:"outputs,omitempty"`
+}
+
+type RdsDatabaseCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type RdsDatabaseCreateAction struct {
+}
+
+func (action *RdsDatabaseCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsDatabaseCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkRdsDatabaseCreateParams(input RdsDatabaseCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.InstanceId == "" {
+		return fmt.Errorf("instance_id is empty")
+	}
+	if input.Name == "" {
+		return fmt.Errorf("name is empty")
+	}
+	if input.CharacterSet != "" {
+		if err := isVaildCharset(input.CharacterSet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createRdsDatabase(input RdsDatabaseCreateInput) (output RdsDatabaseCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkRdsDatabaseCreateParams(input); err != nil {
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	name := input.Name
+	if input.Id != "" {
+		name = input.Id
+	}
+	db, err := getRdsDatabase(sc, input.InstanceId, name)
+	if err != nil {
+		return
+	}
+	if db != nil {
+		output.Id = db.Name
+		return
+	}
+
+	characterSet := "utf8"
+	if input.CharacterSet != "" {
+		characterSet = strings.ToLower(input.CharacterSet)
+	}
+	opts := database.CreateOpts{
+		Dbname:       input.Name,
+		Characterset: characterSet,
+	}
+	if _, err = database.Create(sc, opts, input.InstanceId).Extract(); err != nil {
+		logrus.Errorf("create rds[%v] database(%v) meet err=%v", input.InstanceId, input.Name, err)
+		return
+	}
+	if err = waitRdsDatabaseJobOk(sc, input.InstanceId, input.Name, "create"); err != nil {
+		return
+	}
+	output.Id = input.Name
+	return
+}
+
+func (action *RdsDatabaseCreateAction) Do(inputs interface{}) (interface{}, error) {
+	databases, _ := inputs.(RdsDatabaseCreateInputs)
+	outputs := RdsDatabaseCreateOutputs{}
+	var finalErr error
+
+	for _, input := range databases.Inputs {
+		output, err := createRdsDatabase(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete rds database------------------//
+type RdsDatabaseDeleteInputs struct {
+	Inputs []RdsDatabaseDeleteInput `json:"inputs,omitempty"`
+}
+
+type RdsDatabaseDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	InstanceId string `json:"instance_id,omitempty"`
+}
+
+type RdsDatabaseDeleteOutputs struct {
+	Outputs []RdsDatabaseDeleteOutput `json:"outputs,omitempty"`
+}
+
+type RdsDatabaseDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type RdsDatabaseDeleteAction struct {
+}
+
+func (action *RdsDatabaseDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsDatabaseDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteRdsDatabase(input RdsDatabaseDeleteInput) (output RdsDatabaseDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.InstanceId == "" {
+		err = fmt.Errorf("instance_id is empty")
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	db, err := getRdsDatabase(sc, input.InstanceId, input.Id)
+	if err != nil || db == nil {
+		return
+	}
+
+	if _, err = database.Delete(sc, input.InstanceId, input.Id).Extract(); err != nil {
+		logrus.Errorf("delete rds[%v] database(%v) meet err=%v", input.InstanceId, input.Id, err)
+		return
+	}
+	err = waitRdsDatabaseJobOk(sc, input.InstanceId, input.Id, "delete")
+	return
+}
+
+func (action *RdsDatabaseDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	databases, _ := inputs.(RdsDatabaseDeleteInputs)
+	outputs := RdsDatabaseDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range databases.Inputs {
+		output, err := deleteRdsDatabase(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
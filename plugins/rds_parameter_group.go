@@ -0,0 +1,579 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/configurations"
+	"github.com/huaweicloud/golangsdk"
+	goConfi "github.com/huaweicloud/golangsdk/openstack/rds/v3/configurations"
+	"github.com/sirupsen/logrus"
+)
+
+var rdsParameterGroupActions = make(map[string]Action)
+
+func init() {
+	rdsParameterGroupActions["create"] = new(RdsParameterGroupCreateAction)
+	rdsParameterGroupActions["update"] = new(RdsParameterGroupUpdateAction)
+	rdsParameterGroupActions["delete"] = new(RdsParameterGroupDeleteAction)
+}
+
+type RdsParameterGroupPlugin struct {
+}
+
+func (plugin *RdsParameterGroupPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := rdsParameterGroupActions[actionName]
+	if !found {
+		logrus.Errorf("rds-parameter-group plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("rds-parameter-group plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func listRdsParameterGroups(sc *gophercloud.ServiceClient) ([]configurations.Configurations, error) {
+	allPages, err := configurations.List(sc).AllPages()
+	if err != nil {
+		logrus.Errorf("list rds parameter groups meet err=%v", err)
+		return nil, err
+	}
+	resp, err := configurations.ExtractGetConfigurations(allPages)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ConfigurationsList, nil
+}
+
+func getRdsParameterGroup(sc *gophercloud.ServiceClient, id string) (*configurations.Configurations, error) {
+	groups, err := listRdsParameterGroups(sc)
+	if err != nil {
+		return nil, err
+	}
+	for i, group := range groups {
+		if group.Id == id {
+			return &groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// the allowed parameters of an engine come from its default(not user defined) parameter group
+func getRdsEngineParameters(params CloudProviderParam, engineType string, engineVersion string) (map[string]goConfi.Parameter, error) {
+	sc, err := createRdsServiceClientV3(params)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := listRdsParameterGroups(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultGroupId := ""
+	for _, group := range groups {
+		if !group.UserDefined && strings.EqualFold(group.DatastoreName, engineType) && group.DatastoreVersionName == engineVersion {
+			defaultGroupId = group.Id
+			break
+		}
+	}
+	if defaultGroupId == "" {
+		return nil, fmt.Errorf("can't find the default parameter group of %v %v", engineType, engineVersion)
+	}
+
+	gsc, err := createGolangSdkRdsServiceClientV3(params)
+	if err != nil {
+		return nil, err
+	}
+	group, err := goConfi.Get(gsc, defaultGroupId).Extract()
+	if err != nil {
+		logrus.Errorf("get rds parameter group(%v) meet err=%v", defaultGroupId, err)
+		return nil, err
+	}
+
+	parameters := map[string]goConfi.Parameter{}
+	for _, parameter := range group.Parameters {
+		parameters[parameter.Name] = parameter
+	}
+	return parameters, nil
+}
+
+func checkRdsParameterGroupValues(params CloudProviderParam, engineType string, engineVersion string, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	allowedParameters, err := getRdsEngineParameters(params, engineType, engineVersion)
+	if err != nil {
+		return err
+	}
+	for key := range values {
+		parameter, ok := allowedParameters[key]
+		if !ok {
+			return fmt.Errorf("parameter(%v) is not supported by %v %v", key, engineType, engineVersion)
+		}
+		if parameter.ReadOnly {
+			return fmt.Errorf("parameter(%v) is readonly", key)
+		}
+	}
+	return nil
+}
+
+type RdsParameterGroupCreateInputs struct {
+	Inputs []RdsParameterGroupCreateInput `json:"inputs,omitempty"`
+}
+
+type RdsParameterGroupCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid          string `json:"guid,omitempty"`
+	Id            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	EngineType    string `json:"engine_type,omitempty"`
+	EngineVersion string `json:"engine_version,omitempty"`
+	Parameters    string `json:"parameters,omitempty"` //key1=value1;key2=value2
+}
+
+type RdsParameterGroupOutputs struct {
+	Outputs []RdsParameterGroupOutput `json:"outputs,omitempty"`
+}
+
+type RdsParameterGroupOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type RdsParameterGroupCreateAction struct {
+}
+
+func (action *RdsParameterGroupCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsParameterGroupCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func createRdsParameterGroup(input RdsParameterGroupCreateInput) (output RdsParameterGroupOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Name == "" {
+		err = fmt.Errorf("name is empty")
+		return
+	}
+	engineType := getRdsEngineType(&RdsCreateInput{EngineType: input.EngineType})
+	if err = checkEngineParams(input.CloudProviderParam, engineType, input.EngineVersion); err != nil {
+		return
+	}
+	values, err := getRdsParametersFromString(input.Parameters)
+	if err != nil {
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	if input.Id != "" {
+		group, getErr := getRdsParameterGroup(sc, input.Id)
+		if getErr != nil {
+			err = getErr
+			return
+		}
+		if group != nil {
+			output.Id = group.Id
+			return
+		}
+	}
+
+	if err = checkRdsParameterGroupValues(input.CloudProviderParam, engineType, input.EngineVersion, values); err != nil {
+		return
+	}
+
+	gsc, err := createGolangSdkRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	createOpts := goConfi.CreateOpts{
+		Name:        input.Name,
+		Description: input.Description,
+		Values:      values,
+		DataStore: goConfi.DataStore{
+			Type:    engineType,
+			Version: input.EngineVersion,
+		},
+	}
+	resp, err := goConfi.Create(gsc, createOpts).Extract()
+	if err != nil {
+		logrus.Errorf("create rds parameter group(%v) meet err=%v", input.Name, err)
+		return
+	}
+	output.Id = resp.Id
+	return
+}
+
+func (action *RdsParameterGroupCreateAction) Do(inputs interface{}) (interface{}, error) {
+	groups, _ := inputs.(RdsParameterGroupCreateInputs)
+	outputs := RdsParameterGroupOutputs{}
+	var finalErr error
+
+	for _, input := range groups.Inputs {
+		output, err := createRdsParameterGroup(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------update rds parameter group------------------//
+type RdsParameterGroupUpdateInputs struct {
+	Inputs []RdsParameterGroupUpdateInput `json:"inputs,omitempty"`
+}
+
+type RdsParameterGroupUpdateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Parameters  string `json:"parameters,omitempty"`
+}
+
+type RdsParameterGroupUpdateAction struct {
+}
+
+func (action *RdsParameterGroupUpdateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsParameterGroupUpdateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func updateRdsParameterGroup(input RdsParameterGroupUpdateInput) (output RdsParameterGroupOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	values, err := getRdsParametersFromString(input.Parameters)
+	if err != nil {
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	group, err := getRdsParameterGroup(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if group == nil {
+		err = fmt.Errorf("rds parameter group(%v) is not exist", input.Id)
+		return
+	}
+	if !group.UserDefined {
+		err = fmt.Errorf("rds parameter group(%v) is a default group, can't be updated", input.Id)
+		return
+	}
+	if err = checkRdsParameterGroupValues(input.CloudProviderParam, group.DatastoreName, group.DatastoreVersionName, values); err != nil {
+		return
+	}
+
+	gsc, err := createGolangSdkRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	updateOpts := goConfi.UpdateOpts{
+		Name:        input.Name,
+		Description: input.Description,
+		Values:      values,
+	}
+	if err = goConfi.Update(gsc, input.Id, updateOpts).ExtractErr(); err != nil {
+		logrus.Errorf("update rds parameter group(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *RdsParameterGroupUpdateAction) Do(inputs interface{}) (interface{}, error) {
+	groups, _ := inputs.(RdsParameterGroupUpdateInputs)
+	outputs := RdsParameterGroupOutputs{}
+	var finalErr error
+
+	for _, input := range groups.Inputs {
+		output, err := updateRdsParameterGroup(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete rds parameter group------------------//
+type RdsParameterGroupDeleteInputs struct {
+	Inputs []RdsParameterGroupDeleteInput `json:"inputs,omitempty"`
+}
+
+type RdsParameterGroupDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type RdsParameterGroupDeleteAction struct {
+}
+
+func (action *RdsParameterGroupDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsParameterGroupDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteRdsParameterGroup(input RdsParameterGroupDeleteInput) (output RdsParameterGroupOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	group, err := getRdsParameterGroup(sc, input.Id)
+	if err != nil || group == nil {
+		return
+	}
+
+	err = deleteConfiguration(input.CloudProviderParam, input.Id)
+	if err != nil {
+		logrus.Errorf("delete rds parameter group(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *RdsParameterGroupDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	groups, _ := inputs.(RdsParameterGroupDeleteInputs)
+	outputs := RdsParameterGroupOutputs{}
+	var finalErr error
+
+	for _, input := range groups.Inputs {
+		output, err := deleteRdsParameterGroup(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------apply rds parameter group------------------//
+type RdsApplyParameterGroupInputs struct {
+	Inputs []RdsApplyParameterGroupInput `json:"inputs,omitempty"`
+}
+
+type RdsApplyParameterGroupInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid             string `json:"guid,omitempty"`
+	Id               string `json:"id,omitempty"`
+	ParameterGroupId string `json:"parameter_group_id,omitempty"`
+}
+
+type RdsApplyParameterGroupOutputs struct {
+	Outputs []RdsApplyParameterGroupOutput `json:"outputs,omitempty"`
+}
+
+type RdsApplyParameterGroupOutput struct {
+	CallBackParameter
+	Result
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	IsRestart string `json:"is_restart,omitempty"`
+}
+
+type RdsApplyParameterGroupResult struct {
+	InstanceId          string `json:"instance_id"`
+	NeedRestartInstance bool   `json:"restart_required"`
+	Success             bool   `json:"success"`
+}
+
+type RdsApplyParameterGroupResponse struct {
+	ApplyResults []RdsApplyParameterGroupResult `json:"apply_results"`
+	Success      bool                           `json:"success"`
+}
+
+type RdsApplyParameterGroupAction struct {
+}
+
+func (action *RdsApplyParameterGroupAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsApplyParameterGroupInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func applyRdsParameterGroup(params CloudProviderParam, groupId string, instanceId string) (*RdsApplyParameterGroupResult, error) {
+	gsc, err := createGolangSdkRdsServiceClientV3(params)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"instance_ids": []string{instanceId},
+	}
+	response := RdsApplyParameterGroupResponse{}
+	_, err = gsc.Put(gsc.ServiceURL("configurations", groupId, "apply"), body, &response, &golangsdk.RequestOpts{
+		OkCodes:     []int{200, 202},
+		MoreHeaders: goConfi.RequestOpts.MoreHeaders,
+	})
+	if err != nil {
+		logrus.Errorf("apply rds parameter group(%v) to rds[Id=%v] meet err=%v", groupId, instanceId, err)
+		return nil, err
+	}
+
+	for i, result := range response.ApplyResults {
+		if result.InstanceId != instanceId {
+			continue
+		}
+		if !result.Success {
+			return nil, fmt.Errorf("apply rds parameter group(%v) to rds[Id=%v] failed", groupId, instanceId)
+		}
+		return &response.ApplyResults[i], nil
+	}
+	return nil, fmt.Errorf("can't find the apply result of rds[Id=%v]", instanceId)
+}
+
+func (action *RdsApplyParameterGroupAction) applyParameterGroup(input *RdsApplyParameterGroupInput) (output RdsApplyParameterGroupOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("Rds id is empty")
+		return
+	}
+	if input.ParameterGroupId == "" {
+		err = fmt.Errorf("parameterGroupId is empty")
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	_, ok, err := isRdsExist(sc, input.Id)
+	if err != nil {
+		logrus.Errorf("check whether rds[Id=%v] is exist, meet error=%v", input.Id, err)
+		return
+	}
+	if !ok {
+		err = fmt.Errorf("rds[Id=%v] is not exist", input.Id)
+		return
+	}
+	group, err := getRdsParameterGroup(sc, input.ParameterGroupId)
+	if err != nil {
+		return
+	}
+	if group == nil {
+		err = fmt.Errorf("rds parameter group(%v) is not exist", input.ParameterGroupId)
+		return
+	}
+
+	result, err := applyRdsParameterGroup(input.CloudProviderParam, input.ParameterGroupId, input.Id)
+	if err != nil {
+		return
+	}
+	output.IsRestart = "false"
+	if result.NeedRestartInstance {
+		if err = restartRds(sc, input.Id); err != nil {
+			logrus.Errorf("restart rds[Id=%v] meet err=%v", input.Id, err)
+			return
+		}
+		output.IsRestart = "true"
+	}
+	return
+}
+
+func (action *RdsApplyParameterGroupAction) Do(inputs interface{}) (interface{}, error) {
+	rdss, _ := inputs.(RdsApplyParameterGroupInputs)
+	outputs := RdsApplyParameterGroupOutputs{}
+	var finalErr error
+	for _, rds := range rdss.Inputs {
+		output, err := action.applyParameterGroup(&rds)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all rds instances = %v are applied parameter group", rdss)
+	return &outputs, finalErr
+}
@@ -0,0 +1,367 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/backups"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/instances"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RDS_BACKUP_KEEP_DAYS_MAX = 732
+)
+
+// all the times are UTC
+type RdsPolicyParam struct {
+	BackupKeepDays    string `json:"backup_keep_days,omitempty"`
+	BackupStartTime   string `json:"backup_start_time,omitempty"` //hh:mm-HH:mm, HH=hh+1
+	BackupPeriod      string `json:"backup_period,omitempty"`     //1,2,3,4,5,6,7 means monday to sunday
+	MaintenanceWindow string `json:"maintenance_window,omitempty"`
+	SslEnable         string `json:"ssl_enable,omitempty"`
+}
+
+func isRdsPolicyParamEmpty(param RdsPolicyParam) bool {
+	return param.BackupKeepDays == "" && param.BackupStartTime == "" && param.BackupPeriod == "" &&
+		param.MaintenanceWindow == "" && param.SslEnable == ""
+}
+
+// parse time range like 22:00-02:00
+func parseRdsTimeRange(prefix string, timeRange string) (time.Time, time.Time, error) {
+	times := strings.Split(timeRange, "-")
+	if len(times) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("%v(%v) is invalid", prefix, timeRange)
+	}
+	startTime, err := time.Parse("15:04", times[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%v(%v) is invalid", prefix, timeRange)
+	}
+	endTime, err := time.Parse("15:04", times[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%v(%v) is invalid", prefix, timeRange)
+	}
+	return startTime, endTime, nil
+}
+
+func checkRdsPolicyParams(param RdsPolicyParam, engineType string) error {
+	if param.BackupKeepDays != "" {
+		keepDays, err := strconv.Atoi(param.BackupKeepDays)
+		if err != nil || keepDays < 0 || keepDays > RDS_BACKUP_KEEP_DAYS_MAX {
+			return fmt.Errorf("backupKeepDays(%v) is invalid", param.BackupKeepDays)
+		}
+	}
+
+	if param.BackupStartTime != "" {
+		startTime, endTime, err := parseRdsTimeRange("backupStartTime", param.BackupStartTime)
+		if err != nil {
+			return err
+		}
+		if startTime.Minute()%15 != 0 || startTime.Add(time.Hour).Format("15:04") != endTime.Format("15:04") {
+			return fmt.Errorf("backupStartTime(%v) should be one hour and the minute should be 00,15,30 or 45", param.BackupStartTime)
+		}
+	}
+
+	if param.BackupPeriod != "" {
+		days, err := GetArrayFromString(param.BackupPeriod, ARRAY_SIZE_REAL, 0)
+		if err != nil {
+			return err
+		}
+		for _, day := range days {
+			if err := isValidStringValue("backupPeriod", day, []string{"1", "2", "3", "4", "5", "6", "7"}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if param.MaintenanceWindow != "" {
+		startTime, endTime, err := parseRdsTimeRange("maintenanceWindow", param.MaintenanceWindow)
+		if err != nil {
+			return err
+		}
+		if startTime.Minute() != 0 || endTime.Minute() != 0 || startTime.Equal(endTime) {
+			return fmt.Errorf("maintenanceWindow(%v) should be whole hours", param.MaintenanceWindow)
+		}
+	}
+
+	if param.SslEnable != "" {
+		if err := isValidStringValue("sslEnable", strings.ToLower(param.SslEnable), []string{"true", "false"}); err != nil {
+			return err
+		}
+		if engineType != rdsEngineTypeMap["MYSQL"] {
+			return fmt.Errorf("sslEnable is only supported by %v", rdsEngineTypeMap["MYSQL"])
+		}
+	}
+	return nil
+}
+
+func getRdsBackupPolicy(sc *gophercloud.ServiceClient, id string) (*backups.ListBackupsPolicy, error) {
+	resp := backups.ListAutoBackupsPolicyResp{}
+	_, err := sc.Get(sc.ServiceURL("instances", id, "backups", "policy"), &resp, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"Content-Type": "application/json"},
+	})
+	if err != nil {
+		logrus.Errorf("get rds[Id=%v] backup policy meet err=%v", id, err)
+		return nil, err
+	}
+	return &resp.ListBackupsPolicy, nil
+}
+
+// the empty fields keep the current values
+func updateRdsBackupPolicy(sc *gophercloud.ServiceClient, id string, param RdsPolicyParam) error {
+	if param.BackupKeepDays == "" && param.BackupStartTime == "" && param.BackupPeriod == "" {
+		return nil
+	}
+
+	current, err := getRdsBackupPolicy(sc, id)
+	if err != nil {
+		return err
+	}
+	keepDays := current.KeepDays
+	if param.BackupKeepDays != "" {
+		keepDays, _ = strconv.Atoi(param.BackupKeepDays)
+	}
+	policy := &backups.BackupsPolicy{
+		KeepDays:  &keepDays,
+		StartTime: current.StartTime,
+		Period:    current.Period,
+	}
+	if param.BackupStartTime != "" {
+		policy.StartTime = param.BackupStartTime
+	}
+	if param.BackupPeriod != "" {
+		policy.Period = param.BackupPeriod
+	}
+	// backup is disabled when keep_days is 0, start_time and period are not allowed
+	if keepDays == 0 {
+		policy.StartTime = ""
+		policy.Period = ""
+	}
+
+	err = backups.UpdatePolicy(sc, backups.AutoBackupsPolicyOpts{BackupPolicy: policy}, id).ExtractErr()
+	if err != nil {
+		logrus.Errorf("update rds[Id=%v] backup policy(%++v) meet err=%v", id, policy, err)
+	}
+	return err
+}
+
+func updateRdsMaintenanceWindow(sc *gophercloud.ServiceClient, id string, maintenanceWindow string) error {
+	if maintenanceWindow == "" {
+		return nil
+	}
+	times := strings.Split(maintenanceWindow, "-")
+	body := map[string]interface{}{
+		"start_time": times[0],
+		"end_time":   times[1],
+	}
+	_, err := sc.Put(sc.ServiceURL("instances", id, "ops-window"), body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		logrus.Errorf("update rds[Id=%v] maintenance window(%v) meet err=%v", id, maintenanceWindow, err)
+	}
+	return err
+}
+
+func updateRdsSsl(sc *gophercloud.ServiceClient, id string, sslEnable string) error {
+	if sslEnable == "" {
+		return nil
+	}
+	body := map[string]interface{}{
+		"ssl_option": strings.ToLower(sslEnable) == "true",
+	}
+	_, err := sc.Put(sc.ServiceURL("instances", id, "ssl"), body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		logrus.Errorf("update rds[Id=%v] ssl(%v) meet err=%v", id, sslEnable, err)
+		return err
+	}
+
+	// ssl switch will restart the instance, so wait until the flag is changed and the instance is active again
+	_, err = waitRdsInstanceUpdateOk(sc, id, 20, func(instance *instances.RdsInstanceResponse) bool {
+		enabled, getErr := isRdsSslEnabled(sc, id)
+		return getErr == nil && enabled == (strings.ToLower(sslEnable) == "true")
+	})
+	return err
+}
+
+// the sdk instance has no ssl flag
+func isRdsSslEnabled(sc *gophercloud.ServiceClient, id string) (bool, error) {
+	var resp struct {
+		Instances []struct {
+			EnableSsl bool `json:"enable_ssl"`
+		} `json:"instances"`
+	}
+	_, err := sc.Get(sc.ServiceURL("instances")+"?id="+id, &resp, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("get rds[Id=%v] ssl meet err=%v", id, err)
+		return false, err
+	}
+	if len(resp.Instances) == 0 {
+		return false, fmt.Errorf("rds[Id=%v] is not exist", id)
+	}
+	return resp.Instances[0].EnableSsl, nil
+}
+
+// only the private port could be changed, the public access uses the same port through the bound eip
+func updateRdsPort(sc *gophercloud.ServiceClient, instance *instances.RdsInstanceResponse, port string) error {
+	if port == "" || port == strconv.Itoa(instance.Port) {
+		return nil
+	}
+	portInt, _ := strconv.Atoi(port)
+	body := map[string]interface{}{
+		"port": portInt,
+	}
+	_, err := sc.Put(sc.ServiceURL("instances", instance.Id, "port"), body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		logrus.Errorf("update rds[Id=%v] port(%v) meet err=%v", instance.Id, port, err)
+		return err
+	}
+
+	_, err = waitRdsInstanceUpdateOk(sc, instance.Id, 20, func(instance *instances.RdsInstanceResponse) bool {
+		return instance.Port == portInt
+	})
+	return err
+}
+
+func updateRdsPolicy(sc *gophercloud.ServiceClient, id string, param RdsPolicyParam) error {
+	if err := updateRdsBackupPolicy(sc, id, param); err != nil {
+		return err
+	}
+	if err := updateRdsMaintenanceWindow(sc, id, param.MaintenanceWindow); err != nil {
+		return err
+	}
+	return updateRdsSsl(sc, id, param.SslEnable)
+}
+
+//--------------update rds policy------------------//
+type RdsUpdatePolicyInputs struct {
+	Inputs []RdsUpdatePolicyInput `json:"inputs,omitempty"`
+}
+
+type RdsUpdatePolicyInput struct {
+	CallBackParameter
+	CloudProviderParam
+	RdsPolicyParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+	Port string `json:"port,omitempty"`
+}
+
+type RdsUpdatePolicyOutputs struct {
+	Outputs []RdsUpdatePolicyOutput `json:"outputs,omitempty"`
+}
+
+type RdsUpdatePolicyOutput struct {
+	CallBackParameter
+	Result
+	Guid              string `json:"guid,omitempty"`
+	Id                string `json:"id,omitempty"`
+	Port              string `json:"port,omitempty"`
+	BackupKeepDays    string `json:"backup_keep_days,omitempty"`
+	BackupStartTime   string `json:"backup_start_time,omitempty"`
+	BackupPeriod      string `json:"backup_period,omitempty"`
+	MaintenanceWindow string `json:"maintenance_window,omitempty"`
+}
+
+type RdsUpdatePolicyAction struct {
+}
+
+func (action *RdsUpdatePolicyAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsUpdatePolicyInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *RdsUpdatePolicyAction) updatePolicy(input *RdsUpdatePolicyInput) (output RdsUpdatePolicyOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("Rds id is empty")
+		return
+	}
+	if input.Port != "" {
+		if err = isValidPort(input.Port); err != nil {
+			return
+		}
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	rdsInfo, ok, err := isRdsExist(sc, input.Id)
+	if err != nil {
+		logrus.Errorf("check whether rds[Id=%v] is exist, meet error=%v", input.Id, err)
+		return
+	}
+	if !ok {
+		err = fmt.Errorf("rds[Id=%v] is not exist", input.Id)
+		return
+	}
+	if err = checkRdsPolicyParams(input.RdsPolicyParam, rdsInfo.DataStore.Type); err != nil {
+		return
+	}
+
+	if err = updateRdsPolicy(sc, input.Id, input.RdsPolicyParam); err != nil {
+		return
+	}
+	if err = updateRdsPort(sc, rdsInfo, input.Port); err != nil {
+		return
+	}
+
+	rdsInfo, _, err = isRdsExist(sc, input.Id)
+	if err != nil {
+		return
+	}
+	policy, err := getRdsBackupPolicy(sc, input.Id)
+	if err != nil {
+		return
+	}
+	output.Port = strconv.Itoa(rdsInfo.Port)
+	output.MaintenanceWindow = rdsInfo.MaintenanceWindow
+	output.BackupKeepDays = strconv.Itoa(policy.KeepDays)
+	output.BackupStartTime = policy.StartTime
+	output.BackupPeriod = policy.Period
+	return
+}
+
+func (action *RdsUpdatePolicyAction) Do(inputs interface{}) (interface{}, error) {
+	rdss, _ := inputs.(RdsUpdatePolicyInputs)
+	outputs := RdsUpdatePolicyOutputs{}
+	var finalErr error
+	for _, rds := range rdss.Inputs {
+		output, err := action.updatePolicy(&rds)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all rds instances = %v are updated policy", rdss)
+	return &outputs, finalErr
+}
@@ -0,0 +1,424 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/instances"
+	"github.com/sirupsen/logrus"
+)
+
+type RdsCreateReadReplicaInputs struct {
+	Inputs []RdsCreateReadReplicaInput `json:"inputs,omitempty"`
+}
+
+type RdsCreateReadReplicaInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid                string `json:"guid,omitempty"`
+	Id                  string `json:"id,omitempty"`
+	PrimaryId           string `json:"primary_id,omitempty"`
+	Name                string `json:"name,omitempty"`
+	HostType            string `json:"machine_spec,omitempty"`
+	FlavorType          string `json:"flavor_type,omitempty"`
+	AvailabilityZone    string `json:"az,omitempty"`
+	VolumeType          string `json:"volume_type,omitempty"`
+	VolumeSize          string `json:"volume_size,omitempty"`
+	EnterpriseProjectId string `json:"enterprise_project_id,omitempty"`
+}
+
+type RdsCreateReadReplicaOutputs struct {
+	Outputs []RdsCreateReadReplicaOutput `json:"outputs,omitempty"`
+}
+
+type RdsCreateReadReplicaOutput struct {
+	CallBackParameter
+	Result
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	PrivateIp string `json:"private_ip,omitempty"`
+	Port      string `json:"port,omitempty"`
+	Cpu       string `json:"cpu,omitempty"`
+	Memory    string `json:"memory,omitempty"`
+}
+
+type RdsCreateReadReplicaAction struct {
+}
+
+func (action *RdsCreateReadReplicaAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsCreateReadReplicaInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *RdsCreateReadReplicaAction) checkCreateReadReplicaParams(input RdsCreateReadReplicaInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.PrimaryId == "" {
+		return fmt.Errorf("primaryId is empty")
+	}
+	if input.Name == "" {
+		return fmt.Errorf("name is empty")
+	}
+	if input.HostType == "" {
+		return fmt.Errorf("hostType is empty")
+	}
+	if input.AvailabilityZone == "" {
+		return fmt.Errorf("availabilityZone is empty")
+	}
+	if input.VolumeType != "" && input.VolumeType != RDS_VOLUME_TYPE_ULTRAHIGH && input.VolumeType != RDS_VOLUME_TYPE_ULTRAHIGHPRO {
+		return fmt.Errorf("volumeType is wrong")
+	}
+	return nil
+}
+
+func (action *RdsCreateReadReplicaAction) createReadReplica(input *RdsCreateReadReplicaInput) (output RdsCreateReadReplicaOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = action.checkCreateReadReplicaParams(*input); err != nil {
+		logrus.Errorf("RdsCreateReadReplicaAction checkCreateReadReplicaParams meet error=%v", err)
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	// check whether replica is exist.
+	if input.Id != "" {
+		var replicaInfo *instances.RdsInstanceResponse
+		if replicaInfo, _, err = isRdsExist(sc, input.Id); err != nil {
+			logrus.Errorf("check whether rds replica[Id=%v] is exist, meet error=%v", input.Id, err)
+			return
+		}
+		if replicaInfo != nil {
+			output.Id = replicaInfo.Id
+			output.PrivateIp = replicaInfo.PrivateIps[0]
+			output.Port = strconv.Itoa(replicaInfo.Port)
+			return
+		}
+	}
+
+	primaryInfo, ok, err := isRdsExist(sc, input.PrimaryId)
+	if err != nil {
+		logrus.Errorf("check whether rds[Id=%v] is exist, meet error=%v", input.PrimaryId, err)
+		return
+	}
+	if !ok {
+		err = fmt.Errorf("primary rds[Id=%v] is not exist", input.PrimaryId)
+		return
+	}
+
+	// replica uses the same engine version and by default the same volume as the primary
+	createInput := &RdsCreateInput{
+		CloudProviderParam: input.CloudProviderParam,
+		HostType:           input.HostType,
+		FlavorType:         input.FlavorType,
+		EngineVersion:      primaryInfo.DataStore.Version,
+		EngineType:         primaryInfo.DataStore.Type,
+		VolumeType:         input.VolumeType,
+		VolumeSize:         input.VolumeSize,
+	}
+	if createInput.VolumeType == "" {
+		createInput.VolumeType = primaryInfo.Volume.Type
+	}
+	if createInput.VolumeSize == "" {
+		createInput.VolumeSize = strconv.Itoa(primaryInfo.Volume.Size)
+	}
+
+	azs, err := GetArrayFromString(input.AvailabilityZone, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+	azs = azs[0:1]
+
+	flavor, cpu, memory, err := getRdsFlavorByHostType(createInput, azs, RDS_INSTANCE_MODE_REPLICA)
+	if err != nil {
+		return
+	}
+	volume, err := buildRdsVolumeStruct(createInput)
+	if err != nil {
+		return
+	}
+
+	cloudMap, _ := GetMapFromString(input.CloudProviderParam.CloudParams)
+	request := instances.CreateReplicaOpts{
+		Name:                input.Name,
+		ReplicaOfId:         input.PrimaryId,
+		EnterpriseProjectId: input.EnterpriseProjectId,
+		FlavorRef:           flavor,
+		Volume:              volume,
+		Region:              cloudMap[CLOUD_PARAM_REGION],
+		AvailabilityZone:    azs[0],
+	}
+
+	logrus.Infof("request=%++v", request)
+	response, err := instances.CreateReplica(sc, request).Extract()
+	if err != nil {
+		logrus.Errorf("create rds read replica meet error=%v", err)
+		return
+	}
+	output.Id = response.Instance.Id
+	instance, err := waitRdsInstanceJobOk(sc, response.Instance.Id, "create", 20)
+	if err != nil {
+		return
+	}
+
+	output.PrivateIp = instance.PrivateIps[0]
+	output.Port = strconv.Itoa(instance.Port)
+	output.Cpu = cpu
+	output.Memory = memory
+	return
+}
+
+func (action *RdsCreateReadReplicaAction) Do(inputs interface{}) (interface{}, error) {
+	replicas, _ := inputs.(RdsCreateReadReplicaInputs)
+	outputs := RdsCreateReadReplicaOutputs{}
+	var finalErr error
+	for _, replica := range replicas.Inputs {
+		output, err := action.createReadReplica(&replica)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all rds read replicas = %v are created", replicas)
+	return &outputs, finalErr
+}
+
+type RdsDeleteReadReplicaAction struct {
+}
+
+func (action *RdsDeleteReadReplicaAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *RdsDeleteReadReplicaAction) deleteReadReplica(input *RdsDeleteInput) (output RdsDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("Rds id is empty")
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	replicaInfo, ok, err := isRdsExist(sc, input.Id)
+	if err != nil {
+		logrus.Errorf("check whether rds replica[Id=%v] is exist, meet error=%v", input.Id, err)
+		return
+	}
+	if !ok {
+		logrus.Infof("rds replica[Id=%v] is not exist", input.Id)
+		return
+	}
+	if replicaInfo.Type != RDS_INSTANCE_TYPE_REPLICA {
+		err = fmt.Errorf("rds[Id=%v] is not a read replica, type=%v", input.Id, replicaInfo.Type)
+		return
+	}
+
+	if _, err = instances.Delete(sc, input.Id).Extract(); err != nil {
+		logrus.Errorf("delete rds replica[Id=%v] meet error=%v", input.Id, err)
+		return
+	}
+	_, err = waitRdsInstanceJobOk(sc, input.Id, "delete", 10)
+	if err != nil {
+		logrus.Errorf("waitRdsInstanceJobOk meet error=%v", err)
+	}
+	return
+}
+
+func (action *RdsDeleteReadReplicaAction) Do(inputs interface{}) (interface{}, error) {
+	replicas, _ := inputs.(RdsDeleteInputs)
+	outputs := RdsDeleteOutputs{}
+	var finalErr error
+	for _, replica := range replicas.Inputs {
+		output, err := action.deleteReadReplica(&replica)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all rds read replicas = %v are deleted", replicas)
+	return &outputs, finalErr
+}
+
+//--------------promote standby of ha rds------------------//
+type RdsPromoteInputs struct {
+	Inputs []RdsPromoteInput `json:"inputs,omitempty"`
+}
+
+type RdsPromoteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid  string `json:"guid,omitempty"`
+	Id    string `json:"id,omitempty"`
+	Force string `json:"force,omitempty"`
+}
+
+type RdsPromoteOutputs struct {
+	Outputs []RdsPromoteOutput `json:"outputs,omitempty"`
+}
+
+type RdsPromoteOutput struct {
+	CallBackParameter
+	Result
+	Guid             string `json:"guid,omitempty"`
+	Id               string `json:"id,omitempty"`
+	PrivateIp        string `json:"private_ip,omitempty"`
+	AvailabilityZone string `json:"az,omitempty"`
+}
+
+type RdsPromoteAction struct {
+}
+
+func (action *RdsPromoteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsPromoteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func getRdsMasterNode(instance *instances.RdsInstanceResponse) *instances.Nodes {
+	for i, node := range instance.Nodes {
+		if node.Role == "master" {
+			return &instance.Nodes[i]
+		}
+	}
+	return nil
+}
+
+func failoverRds(sc *gophercloud.ServiceClient, id string, force bool) error {
+	body := map[string]interface{}{
+		"force": force,
+	}
+	url := sc.ServiceURL("instances", id, "action", "failover")
+	_, err := sc.Post(url, body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		logrus.Errorf("failover rds[Id=%v] meet error=%v", id, err)
+	}
+	return err
+}
+
+func (action *RdsPromoteAction) promoteRds(input *RdsPromoteInput) (output RdsPromoteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("Rds id is empty")
+		return
+	}
+	if input.Force != "" {
+		if err = isValidStringValue("force", input.Force, []string{"true", "false"}); err != nil {
+			return
+		}
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	rdsInfo, ok, err := isRdsExist(sc, input.Id)
+	if err != nil {
+		logrus.Errorf("check whether rds[Id=%v] is exist, meet error=%v", input.Id, err)
+		return
+	}
+	if !ok {
+		err = fmt.Errorf("rds[Id=%v] is not exist", input.Id)
+		return
+	}
+	if len(rdsInfo.Nodes) < 2 || getRdsMasterNode(rdsInfo) == nil {
+		err = fmt.Errorf("rds[Id=%v] is not a ha instance, type=%v", input.Id, rdsInfo.Type)
+		return
+	}
+
+	oldMasterId := getRdsMasterNode(rdsInfo).Id
+	if err = failoverRds(sc, input.Id, input.Force == "true"); err != nil {
+		return
+	}
+
+	// the instance is still active before the switchover starts, so wait for the new master
+	instance, err := waitRdsInstanceUpdateOk(sc, input.Id, 20, func(instance *instances.RdsInstanceResponse) bool {
+		master := getRdsMasterNode(instance)
+		return master != nil && master.Id != oldMasterId
+	})
+	if err != nil {
+		return
+	}
+	output.PrivateIp = instance.PrivateIps[0]
+	if master := getRdsMasterNode(instance); master != nil {
+		output.AvailabilityZone = master.AvailabilityZone
+	}
+	return
+}
+
+func (action *RdsPromoteAction) Do(inputs interface{}) (interface{}, error) {
+	rdss, _ := inputs.(RdsPromoteInputs)
+	outputs := RdsPromoteOutputs{}
+	var finalErr error
+	for _, rds := range rdss.Inputs {
+		output, err := action.promoteRds(&rds)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all rds instances = %v are promoted", rdss)
+	return &outputs, finalErr
+}
@@ -0,0 +1,360 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/flavors"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/instances"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RDS_VOLUME_SIZE_STEP = 10
+)
+
+func getRdsNodeAzs(instance *instances.RdsInstanceResponse) []string {
+	azs := []string{}
+	for _, node := range instance.Nodes {
+		found := false
+		for _, az := range azs {
+			if az == node.AvailabilityZone {
+				found = true
+				break
+			}
+		}
+		if !found && node.AvailabilityZone != "" {
+			azs = append(azs, node.AvailabilityZone)
+		}
+	}
+	return azs
+}
+
+// return cpu, ram of the flavor
+func getRdsFlavorCpuAndMemory(sc *gophercloud.ServiceClient, instance *instances.RdsInstanceResponse) (string, string, error) {
+	allPages, err := flavors.List(sc, flavors.DbFlavorsOpts{
+		Versionname: instance.DataStore.Version,
+	}, instance.DataStore.Type).AllPages()
+	if err != nil {
+		return "", "", err
+	}
+	allFlavorsResp, err := flavors.ExtractDbFlavors(allPages)
+	if err != nil {
+		return "", "", err
+	}
+	for _, item := range allFlavorsResp.Flavorslist {
+		if item.Speccode == instance.FlavorRef {
+			return item.Vcpus, strconv.Itoa(item.Ram), nil
+		}
+	}
+	return "", "", fmt.Errorf("can't find rds flavor(%v)", instance.FlavorRef)
+}
+
+func isRdsPrePaid(instance *instances.RdsInstanceResponse) bool {
+	return instance.ChargeInfo.ChargeMode == PRE_PAID
+}
+
+func doRdsInstanceAction(sc *gophercloud.ServiceClient, id string, body map[string]interface{}) error {
+	url := sc.ServiceURL("instances", id, "action")
+	_, err := sc.Post(url, body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	if err != nil {
+		logrus.Errorf("do rds[Id=%v] action(%++v) meet error=%v", id, body, err)
+	}
+	return err
+}
+
+func waitRdsInstanceUpdateOk(sc *gophercloud.ServiceClient, id string, times int, isUpdated func(*instances.RdsInstanceResponse) bool) (*instances.RdsInstanceResponse, error) {
+	count := 1
+	for {
+		instance, ok, err := isRdsExist(sc, id)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("rds instance[id=%v] is not exist", id)
+		}
+		if instance.Status == RDS_INSTANCE_STATUS_BAD {
+			return nil, fmt.Errorf("rds instance[id=%v] status is %v", id, instance.Status)
+		}
+		if instance.Status == RDS_INSTANCE_STATUS_OK && isUpdated(instance) {
+			return instance, nil
+		}
+
+		if count > times {
+			break
+		}
+		time.Sleep(30 * time.Second)
+		count++
+	}
+	return nil, fmt.Errorf("after %vs, update the rds instance[id=%v] is timeout", count*30, id)
+}
+
+//--------------resize rds flavor------------------//
+type RdsResizeFlavorInputs struct {
+	Inputs []RdsResizeFlavorInput `json:"inputs,omitempty"`
+}
+
+type RdsResizeFlavorInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	HostType   string `json:"machine_spec,omitempty"`
+	FlavorType string `json:"flavor_type,omitempty"`
+}
+
+type RdsResizeOutputs struct {
+	Outputs []RdsResizeOutput `json:"outputs,omitempty"`
+}
+
+type RdsResizeOutput struct {
+	CallBackParameter
+	Result
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	Cpu        string `json:"cpu,omitempty"`
+	Memory     string `json:"memory,omitempty"`
+	VolumeSize string `json:"volume_size,omitempty"`
+}
+
+type RdsResizeFlavorAction struct {
+}
+
+func (action *RdsResizeFlavorAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsResizeFlavorInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *RdsResizeFlavorAction) checkResizeFlavorParams(input RdsResizeFlavorInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Id == "" {
+		return fmt.Errorf("Rds id is empty")
+	}
+	if input.HostType == "" {
+		return fmt.Errorf("hostType is empty")
+	}
+	return nil
+}
+
+func (action *RdsResizeFlavorAction) resizeFlavor(input *RdsResizeFlavorInput) (output RdsResizeOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = action.checkResizeFlavorParams(*input); err != nil {
+		logrus.Errorf("RdsResizeFlavorAction checkResizeFlavorParams meet error=%v", err)
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	rdsInfo, ok, err := isRdsExist(sc, input.Id)
+	if err != nil {
+		logrus.Errorf("check whether rds[Id=%v] is exist, meet error=%v", input.Id, err)
+		return
+	}
+	if !ok {
+		err = fmt.Errorf("rds[Id=%v] is not exist", input.Id)
+		return
+	}
+
+	createInput := &RdsCreateInput{
+		CloudProviderParam: input.CloudProviderParam,
+		HostType:           input.HostType,
+		FlavorType:         input.FlavorType,
+		EngineVersion:      rdsInfo.DataStore.Version,
+		EngineType:         rdsInfo.DataStore.Type,
+	}
+	flavor, cpu, memory, err := getRdsFlavorByHostType(createInput, getRdsNodeAzs(rdsInfo), strings.ToLower(rdsInfo.Type))
+	if err != nil {
+		return
+	}
+	output.Cpu = cpu
+	output.Memory = memory
+	output.VolumeSize = strconv.Itoa(rdsInfo.Volume.Size)
+
+	if flavor == rdsInfo.FlavorRef {
+		logrus.Infof("rds[Id=%v] flavor is already %v", input.Id, flavor)
+		return
+	}
+
+	resizeFlavor := map[string]interface{}{
+		"spec_code": flavor,
+	}
+	if isRdsPrePaid(rdsInfo) {
+		resizeFlavor["is_auto_pay"] = true
+	}
+	if err = doRdsInstanceAction(sc, input.Id, map[string]interface{}{"resize_flavor": resizeFlavor}); err != nil {
+		return
+	}
+
+	_, err = waitRdsInstanceUpdateOk(sc, input.Id, 40, func(instance *instances.RdsInstanceResponse) bool {
+		return instance.FlavorRef == flavor
+	})
+	return
+}
+
+func (action *RdsResizeFlavorAction) Do(inputs interface{}) (interface{}, error) {
+	rdss, _ := inputs.(RdsResizeFlavorInputs)
+	outputs := RdsResizeOutputs{}
+	var finalErr error
+	for _, rds := range rdss.Inputs {
+		output, err := action.resizeFlavor(&rds)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all rds instances = %v are resized", rdss)
+	return &outputs, finalErr
+}
+
+//--------------expand rds volume------------------//
+type RdsExpandVolumeInputs struct {
+	Inputs []RdsExpandVolumeInput `json:"inputs,omitempty"`
+}
+
+type RdsExpandVolumeInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	VolumeSize string `json:"volume_size,omitempty"`
+}
+
+type RdsExpandVolumeAction struct {
+}
+
+func (action *RdsExpandVolumeAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsExpandVolumeInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func (action *RdsExpandVolumeAction) checkExpandVolumeParams(input RdsExpandVolumeInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Id == "" {
+		return fmt.Errorf("Rds id is empty")
+	}
+	size, err := strconv.Atoi(input.VolumeSize)
+	if err != nil {
+		return fmt.Errorf("volumeSize(%v) is invalid", input.VolumeSize)
+	}
+	if size%RDS_VOLUME_SIZE_STEP != 0 {
+		return fmt.Errorf("volumeSize(%v) should be a multiple of %v", size, RDS_VOLUME_SIZE_STEP)
+	}
+	return nil
+}
+
+func (action *RdsExpandVolumeAction) expandVolume(input *RdsExpandVolumeInput) (output RdsResizeOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = action.checkExpandVolumeParams(*input); err != nil {
+		logrus.Errorf("RdsExpandVolumeAction checkExpandVolumeParams meet error=%v", err)
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	rdsInfo, ok, err := isRdsExist(sc, input.Id)
+	if err != nil {
+		logrus.Errorf("check whether rds[Id=%v] is exist, meet error=%v", input.Id, err)
+		return
+	}
+	if !ok {
+		err = fmt.Errorf("rds[Id=%v] is not exist", input.Id)
+		return
+	}
+
+	output.Cpu, output.Memory, err = getRdsFlavorCpuAndMemory(sc, rdsInfo)
+	if err != nil {
+		return
+	}
+
+	size, _ := strconv.Atoi(input.VolumeSize)
+	output.VolumeSize = strconv.Itoa(rdsInfo.Volume.Size)
+	if size == rdsInfo.Volume.Size {
+		logrus.Infof("rds[Id=%v] volume size is already %v", input.Id, size)
+		return
+	}
+	if size < rdsInfo.Volume.Size {
+		err = fmt.Errorf("volumeSize(%v) is smaller than current size(%v)", size, rdsInfo.Volume.Size)
+		return
+	}
+
+	enlargeVolume := map[string]interface{}{
+		"size": size,
+	}
+	if isRdsPrePaid(rdsInfo) {
+		enlargeVolume["is_auto_pay"] = true
+	}
+	if err = doRdsInstanceAction(sc, input.Id, map[string]interface{}{"enlarge_volume": enlargeVolume}); err != nil {
+		return
+	}
+
+	_, err = waitRdsInstanceUpdateOk(sc, input.Id, 40, func(instance *instances.RdsInstanceResponse) bool {
+		return instance.Volume.Size == size
+	})
+	if err != nil {
+		return
+	}
+	output.VolumeSize = strconv.Itoa(size)
+	return
+}
+
+func (action *RdsExpandVolumeAction) Do(inputs interface{}) (interface{}, error) {
+	rdss, _ := inputs.(RdsExpandVolumeInputs)
+	outputs := RdsResizeOutputs{}
+	var finalErr error
+	for _, rds := range rdss.Inputs {
+		output, err := action.expandVolume(&rds)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all rds instances = %v are expanded", rdss)
+	return &outputs, finalErr
+}
@@ -0,0 +1,427 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WeBankPartners/wecube-plugins-huaweicloud/plugins/utils"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/backups"
+	"github.com/gophercloud/gophercloud/openstack/rds/v3/instances"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RDS_RESTORE_TYPE_BACKUP    = "backup"
+	RDS_RESTORE_TYPE_TIMESTAMP = "timestamp"
+
+	RDS_RESTORE_TARGET_NEW    = "new"
+	RDS_RESTORE_TARGET_SOURCE = "source"
+
+	RDS_JOB_STATUS_OK  = "Completed"
+	RDS_JOB_STATUS_BAD = "Failed"
+
+	RDS_RESTORE_TIME_FORMAT = time.RFC3339
+)
+
+type RdsRestoreInputs struct {
+	Inputs []RdsRestoreInput `json:"inputs,omitempty"`
+}
+
+type RdsRestoreInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid          string `json:"guid,omitempty"`
+	Id            string `json:"id,omitempty"`
+	Seed          string `json:"seed,omitempty"`
+	InstanceId    string `json:"instance_id,omitempty"`
+	BackupId      string `json:"backup_id,omitempty"`
+	RestoreTime   string `json:"restore_time,omitempty"` //unix毫秒时间戳或带时区的 2006-01-02T15:04:05+08:00
+	RestoreTarget string `json:"restore_target,omitempty"`
+
+	//恢复到新实例时的参数,为空则沿用源实例的配置
+	Name              string `json:"name,omitempty"`
+	Password          string `json:"password,omitempty"`
+	Port              string `json:"port,omitempty"`
+	HostType          string `json:"machine_spec,omitempty"`
+	FlavorType        string `json:"flavor_type,omitempty"`
+	SecurityGroupId   string `json:"security_group_id,omitempty"`
+	VpcId             string `json:"vpc_id,omitempty"`
+	SubnetId          string `json:"subnet_id,omitempty"`
+	AvailabilityZone  string `json:"az,omitempty"`
+	SupportHa         string `json:"support_ha,omitempty"`
+	HaReplicationMode string `json:"ha_replication_mode,omitempty"`
+	VolumeType        string `json:"volume_type,omitempty"`
+	VolumeSize        string `json:"volume_size,omitempty"`
+}
+
+type RdsRestoreOutputs struct {
+	Outputs []RdsRestoreOutput `json:"outputs,omitempty"`
+}
+
+type RdsRestoreOutput struct {
+	CallBackParameter
+	Result
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	PrivateIp string `json:"private_ip,omitempty"`
+	Port      string `json:"port,omitempty"`
+	UserName  string `json:"user_name,omitempty"`
+	Password  string `json:"password,omitempty"`
+	Cpu       string `json:"cpu,omitempty"`
+	Memory    string `json:"memory,omitempty"`
+}
+
+type RdsRestoreAction struct {
+}
+
+func (action *RdsRestoreAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RdsRestoreInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// restore time could be unix timestamp in milliseconds or rfc3339 like "2006-01-02T15:04:05+08:00",
+// the offset is required so the restore point doesn't depend on the plugin's timezone
+func parseRdsRestoreTime(restoreTime string) (int, error) {
+	if timestamp, err := strconv.Atoi(restoreTime); err == nil {
+		return timestamp, nil
+	}
+	t, err := time.Parse(RDS_RESTORE_TIME_FORMAT, restoreTime)
+	if err != nil {
+		return 0, fmt.Errorf("restoreTime(%v) is invalid, should be unix milliseconds or rfc3339 with offset", restoreTime)
+	}
+	return int(t.UnixNano() / int64(time.Millisecond)), nil
+}
+
+func (action *RdsRestoreAction) checkRestoreParams(input RdsRestoreInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.InstanceId == "" {
+		return fmt.Errorf("instanceId is empty")
+	}
+	if input.BackupId == "" && input.RestoreTime == "" {
+		return fmt.Errorf("backupId and restoreTime are both empty")
+	}
+	if input.BackupId != "" && input.RestoreTime != "" {
+		return fmt.Errorf("backupId and restoreTime can't be set at the same time")
+	}
+	if input.RestoreTime != "" {
+		if _, err := parseRdsRestoreTime(input.RestoreTime); err != nil {
+			return err
+		}
+	}
+	if err := isValidStringValue("restoreTarget", input.RestoreTarget, []string{RDS_RESTORE_TARGET_NEW, RDS_RESTORE_TARGET_SOURCE}); err != nil {
+		return err
+	}
+
+	if input.RestoreTarget == RDS_RESTORE_TARGET_NEW {
+		if input.Seed == "" {
+			return fmt.Errorf("seed is empty")
+		}
+		if input.Name == "" {
+			return fmt.Errorf("name is empty")
+		}
+		if input.HostType == "" {
+			return fmt.Errorf("hostType is empty")
+		}
+		if input.AvailabilityZone == "" {
+			return fmt.Errorf("availabilityZone is empty")
+		}
+		if input.VolumeType != "" && input.VolumeType != RDS_VOLUME_TYPE_ULTRAHIGH && input.VolumeType != RDS_VOLUME_TYPE_ULTRAHIGHPRO {
+			return fmt.Errorf("volumeType is wrong")
+		}
+		if strings.ToLower(input.SupportHa) == "true" && input.HaReplicationMode == "" {
+			return fmt.Errorf("haReplicationMode is empty")
+		}
+	}
+	return nil
+}
+
+func buildRdsRestorePoint(sc *gophercloud.ServiceClient, input *RdsRestoreInput) (*backups.RestorePoint, error) {
+	restorePoint := &backups.RestorePoint{
+		InstanceId: input.InstanceId,
+	}
+	if input.BackupId != "" {
+		backup, ok, err := isRdsBackupExist(sc, input.BackupId, input.InstanceId)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("backup[%v] of rds[%v] is not exist", input.BackupId, input.InstanceId)
+		}
+		if backup.Status != RDS_BACKUP_STATUS_OK {
+			return nil, fmt.Errorf("backup[%v] status is %v", input.BackupId, backup.Status)
+		}
+		restorePoint.Type = RDS_RESTORE_TYPE_BACKUP
+		restorePoint.BackupId = input.BackupId
+		return restorePoint, nil
+	}
+
+	restoreTime, _ := parseRdsRestoreTime(input.RestoreTime)
+	if err := isRdsRestoreTimeValid(sc, input.InstanceId, restoreTime); err != nil {
+		return nil, err
+	}
+	restorePoint.Type = RDS_RESTORE_TYPE_TIMESTAMP
+	restorePoint.RestoreTime = restoreTime
+	return restorePoint, nil
+}
+
+func isRdsRestoreTimeValid(sc *gophercloud.ServiceClient, instanceId string, restoreTime int) error {
+	allPages, err := backups.ListRestoreTime(sc, backups.ListRestoreTimeOpts{}, instanceId).AllPages()
+	if err != nil {
+		logrus.Errorf("list rds[%v] restore time meet err=%v", instanceId, err)
+		return err
+	}
+	restoreTimes, err := backups.ExtractRestoreTime(allPages)
+	if err != nil {
+		return err
+	}
+	for _, item := range restoreTimes.RestoreTimeList {
+		if restoreTime >= item.StartTime && restoreTime <= item.EndTime {
+			return nil
+		}
+	}
+	return fmt.Errorf("restoreTime(%v) is not in the restorable time range of rds[%v]", restoreTime, instanceId)
+}
+
+func waitRdsJobOk(sc *gophercloud.ServiceClient, jobId string, times int) error {
+	var resp struct {
+		Job struct {
+			Status string `json:"status"`
+			Fail   string `json:"fail_reason"`
+		} `json:"job"`
+	}
+
+	count := 1
+	for {
+		_, err := sc.Get(sc.ServiceURL("jobs")+"?id="+jobId, &resp, &gophercloud.RequestOpts{
+			MoreHeaders: map[string]string{"Content-Type": "application/json"},
+		})
+		if err != nil {
+			logrus.Errorf("get rds job[%v] meet err=%v", jobId, err)
+			return err
+		}
+		if resp.Job.Status == RDS_JOB_STATUS_OK {
+			return nil
+		}
+		if resp.Job.Status == RDS_JOB_STATUS_BAD {
+			return fmt.Errorf("rds job[%v] failed, reason=%v", jobId, resp.Job.Fail)
+		}
+
+		if count > times {
+			break
+		}
+		time.Sleep(30 * time.Second)
+		count++
+	}
+	return fmt.Errorf("after %vs, the rds job[id=%v] is timeout", count*30, jobId)
+}
+
+func (action *RdsRestoreAction) restoreToSource(sc *gophercloud.ServiceClient, input *RdsRestoreInput, restorePoint *backups.RestorePoint) (*instances.RdsInstanceResponse, error) {
+	opts := backups.RecoveryOpts{
+		Source: backups.Source{
+			InstanceId:  restorePoint.InstanceId,
+			Type:        restorePoint.Type,
+			BackupId:    restorePoint.BackupId,
+			RestoreTime: restorePoint.RestoreTime,
+		},
+		Target: backups.Target{
+			InstanceId: input.InstanceId,
+		},
+	}
+	resp, err := backups.Recovery(sc, opts).Extract()
+	if err != nil {
+		logrus.Errorf("recovery rds[%v] meet err=%v", input.InstanceId, err)
+		return nil, err
+	}
+	if err = waitRdsJobOk(sc, resp.JobId, 40); err != nil {
+		return nil, err
+	}
+	return waitRdsInstanceJobOk(sc, input.InstanceId, "create", 20)
+}
+
+func (action *RdsRestoreAction) restoreToNew(sc *gophercloud.ServiceClient, input *RdsRestoreInput, restorePoint *backups.RestorePoint, source *instances.RdsInstanceResponse) (*instances.RdsInstanceResponse, string, string, error) {
+	createInput := &RdsCreateInput{
+		CloudProviderParam: input.CloudProviderParam,
+		HostType:           input.HostType,
+		FlavorType:         input.FlavorType,
+		EngineVersion:      source.DataStore.Version,
+		EngineType:         source.DataStore.Type,
+		AvailabilityZone:   input.AvailabilityZone,
+		SupportHa:          input.SupportHa,
+		VolumeType:         input.VolumeType,
+		VolumeSize:         input.VolumeSize,
+	}
+	if createInput.VolumeType == "" {
+		createInput.VolumeType = source.Volume.Type
+	}
+	if createInput.VolumeSize == "" {
+		createInput.VolumeSize = strconv.Itoa(source.Volume.Size)
+	}
+
+	azs, err := getAzs(createInput)
+	if err != nil {
+		return nil, "", "", err
+	}
+	flavor, cpu, memory, err := getRdsFlavorByHostType(createInput, azs, getRdsInstanceMode(createInput))
+	if err != nil {
+		return nil, "", "", err
+	}
+	volume, err := buildRdsVolumeStruct(createInput)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if volume.Size < source.Volume.Size {
+		return nil, "", "", fmt.Errorf("volumeSize(%v) is smaller than source size(%v)", volume.Size, source.Volume.Size)
+	}
+
+	request := backups.RestoreNewRdsOpts{
+		Name:             input.Name,
+		Password:         input.Password,
+		Port:             input.Port,
+		FlavorRef:        flavor,
+		Volume:           &backups.Volume{Type: volume.Type, Size: volume.Size},
+		AvailabilityZone: strings.Join(azs, ","),
+		VpcId:            input.VpcId,
+		SubnetId:         input.SubnetId,
+		SecurityGroupId:  input.SecurityGroupId,
+		RestorePoint:     restorePoint,
+	}
+	if request.VpcId == "" {
+		request.VpcId = source.VpcId
+	}
+	if request.SubnetId == "" {
+		request.SubnetId = source.SubnetId
+	}
+	if request.SecurityGroupId == "" {
+		request.SecurityGroupId = source.SecurityGroupId
+	}
+	if strings.ToLower(input.SupportHa) == "true" {
+		request.Ha = &backups.Ha{
+			Mode:            "Ha",
+			ReplicationMode: input.HaReplicationMode,
+		}
+	}
+
+	response, err := backups.Restore(sc, request).Extract()
+	if err != nil {
+		logrus.Errorf("restore rds[%v] to new instance meet err=%v", input.InstanceId, err)
+		return nil, "", "", err
+	}
+	instance, err := waitRdsInstanceJobOk(sc, response.Instance.ID, "create", 40)
+	if err != nil {
+		return &instances.RdsInstanceResponse{Id: response.Instance.ID}, "", "", err
+	}
+	return instance, cpu, memory, nil
+}
+
+func (action *RdsRestoreAction) restoreRds(input *RdsRestoreInput) (output RdsRestoreOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = action.checkRestoreParams(*input); err != nil {
+		logrus.Errorf("RdsRestoreAction checkRestoreParams meet error=%v", err)
+		return
+	}
+
+	sc, err := createRdsServiceClientV3(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	// check whether the restored instance is exist.
+	if input.Id != "" && input.RestoreTarget == RDS_RESTORE_TARGET_NEW {
+		var rdsInfo *instances.RdsInstanceResponse
+		if rdsInfo, _, err = isRdsExist(sc, input.Id); err != nil {
+			logrus.Errorf("check whether rds[Id=%v] is exist, meet error=%v", input.Id, err)
+			return
+		}
+		if rdsInfo != nil {
+			output.Id = rdsInfo.Id
+			output.PrivateIp = rdsInfo.PrivateIps[0]
+			output.Port = strconv.Itoa(rdsInfo.Port)
+			output.UserName = rdsInfo.DbUserName
+			return
+		}
+	}
+
+	source, ok, err := isRdsExist(sc, input.InstanceId)
+	if err != nil {
+		logrus.Errorf("check whether rds[Id=%v] is exist, meet error=%v", input.InstanceId, err)
+		return
+	}
+	if !ok {
+		err = fmt.Errorf("source rds[Id=%v] is not exist", input.InstanceId)
+		return
+	}
+
+	restorePoint, err := buildRdsRestorePoint(sc, input)
+	if err != nil {
+		return
+	}
+
+	var instance *instances.RdsInstanceResponse
+	if input.RestoreTarget == RDS_RESTORE_TARGET_SOURCE {
+		instance, err = action.restoreToSource(sc, input, restorePoint)
+		if err != nil {
+			return
+		}
+		output.Cpu, output.Memory, err = getRdsFlavorCpuAndMemory(sc, instance)
+		if err != nil {
+			return
+		}
+	} else {
+		if input.Password == "" {
+			input.Password = utils.CreateRandomPassword()
+		}
+		instance, output.Cpu, output.Memory, err = action.restoreToNew(sc, input, restorePoint, source)
+		if instance != nil {
+			output.Id = instance.Id
+		}
+		if err != nil {
+			return
+		}
+
+		output.Password, err = utils.AesEnPassword(input.Guid, input.Seed, input.Password, utils.DEFALT_CIPHER)
+		if err != nil {
+			return
+		}
+	}
+
+	output.Id = instance.Id
+	output.PrivateIp = instance.PrivateIps[0]
+	output.Port = strconv.Itoa(instance.Port)
+	output.UserName = instance.DbUserName
+	return
+}
+
+func (action *RdsRestoreAction) Do(inputs interface{}) (interface{}, error) {
+	rdss, _ := inputs.(RdsRestoreInputs)
+	outputs := RdsRestoreOutputs{}
+	var finalErr error
+	for _, rds := range rdss.Inputs {
+		output, err := action.restoreRds(&rds)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all rds instances = %v are restored", rdss)
+	return &outputs, finalErr
+}
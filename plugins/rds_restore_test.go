@@ -0,0 +1,30 @@
+package plugins
+
+import (
+	"testing"
+)
+
+func TestParseRdsRestoreTime(t *testing.T) {
+	cases := []struct {
+		restoreTime string
+		expected    int
+		isErr       bool
+	}{
+		{"1577836800000", 1577836800000, false},
+		{"2020-01-01T00:00:00Z", 1577836800000, false},
+		{"2020-01-01T08:00:00+08:00", 1577836800000, false},
+		{"2020-01-01 08:00:00", 0, true},
+		{"", 0, true},
+	}
+
+	for _, c := range cases {
+		restoreTime, err := parseRdsRestoreTime(c.restoreTime)
+		if (err != nil) != c.isErr {
+			t.Errorf("parseRdsRestoreTime(%v) err=%v, expected err=%v", c.restoreTime, err, c.isErr)
+			continue
+		}
+		if restoreTime != c.expected {
+			t.Errorf("parseRdsRestoreTime(%v)=%v, expected %v", c.restoreTime, restoreTime, c.expected)
+		}
+	}
+}
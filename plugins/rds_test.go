@@ -0,0 +1,103 @@
+package plugins
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetRdsFlavorTypeFromSpecCode(t *testing.T) {
+	cases := []struct {
+		specCode string
+		expected string
+	}{
+		{"rds.mysql.c2.large.ha", "c2"},
+		{"rds.mysql.s1.medium", "s1"},
+		{"rds.pg.c2.xlarge.rr", "c2"},
+		{"rds.mssql.se.s3.large.2.ha", "s3"},
+		{"rds.mssql", ""},
+		{"", ""},
+	}
+
+	for _, c := range cases {
+		if flavorType := getRdsFlavorTypeFromSpecCode(c.specCode); flavorType != c.expected {
+			t.Errorf("getRdsFlavorTypeFromSpecCode(%v)=%v, expected %v", c.specCode, flavorType, c.expected)
+		}
+	}
+}
+
+func TestBuildRdsConfigurationValues(t *testing.T) {
+	cases := []struct {
+		input    RdsCreateInput
+		expected map[string]string
+	}{
+		{
+			RdsCreateInput{EngineType: "MYSQL"},
+			map[string]string{"character_set_server": "utf8", "lower_case_table_names": "1"},
+		},
+		{
+			RdsCreateInput{EngineType: "MYSQL", ParameterGroupId: "group"},
+			map[string]string{},
+		},
+		{
+			RdsCreateInput{EngineType: "MYSQL", ParameterGroupId: "group", LowerCaseTableNames: "0"},
+			map[string]string{"lower_case_table_names": "0"},
+		},
+		{
+			RdsCreateInput{EngineType: "MYSQL", ParameterGroupId: "group", CharacterSet: "UTF8MB4"},
+			map[string]string{"character_set_server": "utf8mb4"},
+		},
+	}
+
+	for _, c := range cases {
+		value := buildRdsConfigurationValues(&c.input)
+		if !reflect.DeepEqual(value, c.expected) {
+			t.Errorf("buildRdsConfigurationValues(%++v)=%v, expected %v", c.input, value, c.expected)
+		}
+	}
+}
+
+// the values which createRds sends after checking the params
+func TestCheckAndBuildRdsConfigurationValues(t *testing.T) {
+	cases := []struct {
+		input    RdsCreateInput
+		expected map[string]string
+		isErr    bool
+	}{
+		{
+			RdsCreateInput{ParameterGroupId: "group"},
+			map[string]string{},
+			false,
+		},
+		{
+			RdsCreateInput{EngineType: "MYSQL", ParameterGroupId: "group", Parameters: "max_connections=1000"},
+			map[string]string{"max_connections": "1000"},
+			false,
+		},
+		{
+			RdsCreateInput{EngineType: "MYSQL"},
+			map[string]string{"character_set_server": "utf8", "lower_case_table_names": "1"},
+			false,
+		},
+		{
+			RdsCreateInput{EngineType: "MYSQL", CharacterSet: "gbk", LowerCaseTableNames: "0"},
+			map[string]string{"character_set_server": "gbk", "lower_case_table_names": "0"},
+			false,
+		},
+		{RdsCreateInput{EngineType: "MYSQL", ParameterGroupId: "group", CharacterSet: "big5"}, nil, true},
+		{RdsCreateInput{EngineType: "MYSQL", LowerCaseTableNames: "2"}, nil, true},
+	}
+
+	for _, c := range cases {
+		err := checkRdsConfigurationParams(&c.input, getRdsEngineType(&c.input))
+		if (err != nil) != c.isErr {
+			t.Errorf("checkRdsConfigurationParams(%++v) err=%v, expected err=%v", c.input, err, c.isErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if value := buildRdsConfigurationValues(&c.input); !reflect.DeepEqual(value, c.expected) {
+			t.Errorf("buildRdsConfigurationValues(%++v)=%v, expected %v", c.input, value, c.expected)
+		}
+	}
+}
@@ -45,6 +45,9 @@ type RouteCreateInput struct {
 	Nexthop     string `json:"nexthop,omitempty"`
 	Type        string `json:"type,omitempty"`
 	VpcId       string `json:"vpc_id,omitempty"`
+
+	// the route is added to the custom route table if not empty
+	RouteTableId string `json:"route_table_id,omitempty"`
 }
 
 type RouteCreateOutputs struct {
@@ -70,6 +73,49 @@ func (action *RouteCreateAction) ReadParam(param interface{}) (interface{}, erro
 	return inputs, nil
 }
 
+func getRouteTableRouteFromInput(input RouteCreateInput) routeTableRoute {
+	return routeTableRoute{
+		Type:        strings.ToLower(input.Type),
+		Destination: input.Destination,
+		Nexthop:     input.Nexthop,
+	}
+}
+
+// routes of the custom route table have no id, the destination is unique in the table
+func addRouteTableRoute(sc *gophercloud.ServiceClient, tableId string, route routeTableRoute) error {
+	table, exist, err := getRouteTableById(sc, tableId)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return fmt.Errorf("route table(%v) is not exist", tableId)
+	}
+	for _, tableRoute := range table.Routes {
+		if tableRoute.Destination != route.Destination {
+			continue
+		}
+		if tableRoute.Type == route.Type && tableRoute.Nexthop == route.Nexthop {
+			return nil
+		}
+		return fmt.Errorf("route table(%v) already has route(%v) to %v %v", tableId, route.Destination, tableRoute.Type, tableRoute.Nexthop)
+	}
+	return updateRouteTableRoutes(sc, tableId, []routeTableRoute{route}, nil)
+}
+
+func deleteRouteTableRoute(sc *gophercloud.ServiceClient, tableId string, destination string) error {
+	table, exist, err := getRouteTableById(sc, tableId)
+	if err != nil || !exist {
+		return err
+	}
+	for _, tableRoute := range table.Routes {
+		if tableRoute.Destination == destination {
+			return updateRouteTableRoutes(sc, tableId, nil, []routeTableRoute{tableRoute})
+		}
+	}
+	logrus.Infof("route(%v) is not exist in route table(%v)", destination, tableId)
+	return nil
+}
+
 func (acton *RouteCreateAction) checkCreaterouteParams(input RouteCreateInput) error {
 	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
 		return err
@@ -81,6 +127,9 @@ func (acton *RouteCreateAction) checkCreaterouteParams(input RouteCreateInput) e
 	if input.Nexthop == "" {
 		return fmt.Errorf("Nexthop is empty")
 	}
+	if input.RouteTableId != "" {
+		return checkRouteTableRoute(input.CloudProviderParam, getRouteTableRouteFromInput(input))
+	}
 
 	if input.Type == "" {
 		return fmt.Errorf("Type is empty")
@@ -118,6 +167,11 @@ func (action *RouteCreateAction) createRoute(input *RouteCreateInput) (output Ro
 		return
 	}
 
+	if input.RouteTableId != "" {
+		err = addRouteTableRoute(sc, input.RouteTableId, getRouteTableRouteFromInput(*input))
+		return
+	}
+
 	// check whether is exist.
 	if input.Id != "" {
 		var routeInfo *routes.Route
@@ -188,6 +242,10 @@ type RouteDeleteInput struct {
 	CloudProviderParam
 	Guid string `json:"guid,omitempty"`
 	Id   string `json:"id,omitempty"`
+
+	// the route of the custom route table is deleted by destination
+	RouteTableId string `json:"route_table_id,omitempty"`
+	Destination  string `json:"destination,omitempty"`
 }
 
 type RouteDeleteOutputs struct {
@@ -217,6 +275,12 @@ func (action *RouteDeleteAction) checkDeleteRouteParams(input RouteDeleteInput)
 	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
 		return err
 	}
+	if input.RouteTableId != "" {
+		if input.Destination == "" {
+			return fmt.Errorf("Destination is empty")
+		}
+		return nil
+	}
 	if input.Id == "" {
 		return fmt.Errorf("Route id is empty")
 	}
@@ -248,6 +312,11 @@ func (action *RouteDeleteAction) deleteRoute(input *RouteDeleteInput) (output Ro
 		return
 	}
 
+	if input.RouteTableId != "" {
+		err = deleteRouteTableRoute(sc, input.RouteTableId, input.Destination)
+		return
+	}
+
 	// check whether route is exist.
 	_, ok, err := isRouteExist(sc, input.Id)
 	if err != nil {
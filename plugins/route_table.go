@@ -0,0 +1,613 @@
+package plugins
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ROUTE_TABLE_NEXTHOP_ECS     = "ecs"
+	ROUTE_TABLE_NEXTHOP_VIP     = "vip"
+	ROUTE_TABLE_NEXTHOP_NAT     = "nat"
+	ROUTE_TABLE_NEXTHOP_PEERING = "peering"
+	ROUTE_TABLE_NEXTHOP_VPN     = "vpn"
+)
+
+var routeTableActions = make(map[string]Action)
+var routeTableNexthopIdRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func init() {
+	routeTableActions["create"] = new(RouteTableCreateAction)
+	routeTableActions["delete"] = new(RouteTableDeleteAction)
+	routeTableActions["associate-subnets"] = new(RouteTableAssociateAction)
+	routeTableActions["disassociate"] = new(RouteTableDisassociateAction)
+}
+
+type RouteTablePlugin struct {
+}
+
+func (plugin *RouteTablePlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := routeTableActions[actionName]
+	if !found {
+		logrus.Errorf("route-table plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("route-table plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+type routeTableRoute struct {
+	Type        string `json:"type"`
+	Destination string `json:"destination"`
+	Nexthop     string `json:"nexthop"`
+	Description string `json:"description,omitempty"`
+}
+
+type routeTableSubnet struct {
+	Id string `json:"id"`
+}
+
+type routeTable struct {
+	Id          string             `json:"id"`
+	Name        string             `json:"name"`
+	Default     bool               `json:"default"`
+	VpcId       string             `json:"vpc_id"`
+	Description string             `json:"description"`
+	Routes      []routeTableRoute  `json:"routes"`
+	Subnets     []routeTableSubnet `json:"subnets"`
+}
+
+func (table *routeTable) getSubnetIds() []string {
+	subnetIds := []string{}
+	for _, subnet := range table.Subnets {
+		subnetIds = append(subnetIds, subnet.Id)
+	}
+	return subnetIds
+}
+
+func isRouteTableNotFoundErr(err error) bool {
+	if ue, ok := err.(*gophercloud.UnifiedError); ok {
+		message := strings.ToLower(ue.Message())
+		return strings.Contains(message, "could not be found") || strings.Contains(message, "not exist") || strings.Contains(message, "not found")
+	}
+	return false
+}
+
+func getRouteTableById(sc *gophercloud.ServiceClient, id string) (*routeTable, bool, error) {
+	resp := struct {
+		RouteTable routeTable `json:"routetable"`
+	}{}
+	_, err := sc.Get(sc.ServiceURL("routetables", id), &resp, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		if isRouteTableNotFoundErr(err) {
+			return nil, false, nil
+		}
+		logrus.Errorf("get route table(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	return &resp.RouteTable, true, nil
+}
+
+// the routes are not in the list, get the route table for them
+func listRouteTables(sc *gophercloud.ServiceClient, vpcId string) ([]routeTable, error) {
+	resp := struct {
+		RouteTables []routeTable `json:"routetables"`
+	}{}
+	_, err := sc.Get(sc.ServiceURL("routetables")+"?vpc_id="+vpcId, &resp, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("list vpc(%v) route tables meet err=%v", vpcId, err)
+		return nil, err
+	}
+	return resp.RouteTables, nil
+}
+
+func updateRouteTableRoutes(sc *gophercloud.ServiceClient, id string, addRoutes []routeTableRoute, delRoutes []routeTableRoute) error {
+	routes := map[string]interface{}{}
+	if len(addRoutes) > 0 {
+		routes["add"] = addRoutes
+	}
+	if len(delRoutes) > 0 {
+		routes["del"] = delRoutes
+	}
+	body := map[string]interface{}{
+		"routetable": map[string]interface{}{
+			"routes": routes,
+		},
+	}
+	_, err := sc.Put(sc.ServiceURL("routetables", id), body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("update route table(%v) routes meet err=%v", id, err)
+	}
+	return err
+}
+
+func updateRouteTableSubnets(sc *gophercloud.ServiceClient, id string, subnetIds []string, isAssociate bool) error {
+	if len(subnetIds) == 0 {
+		return nil
+	}
+	operation := "disassociate"
+	if isAssociate {
+		operation = "associate"
+	}
+	body := map[string]interface{}{
+		"routetable": map[string]interface{}{
+			"subnets": map[string]interface{}{
+				operation: subnetIds,
+			},
+		},
+	}
+	_, err := sc.Post(sc.ServiceURL("routetables", id, "action"), body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("%v route table(%v) subnets(%v) meet err=%v", operation, id, subnetIds, err)
+	}
+	return err
+}
+
+// routes are separated by comma, each route is "type destination nexthop". e.g. "nat 0.0.0.0/0 natId,vip 10.1.0.0/16 192.168.0.10"
+func parseRouteTableRoutes(rawRoutes string) ([]routeTableRoute, error) {
+	routes := []routeTableRoute{}
+	entries, err := GetArrayFromString(rawRoutes, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return routes, err
+	}
+	for _, entry := range entries {
+		fields := strings.Fields(entry)
+		if len(fields) != 3 {
+			return routes, fmt.Errorf("route(%v) is invalid, should be \"type destination nexthop\"", entry)
+		}
+		routes = append(routes, routeTableRoute{
+			Type:        strings.ToLower(fields[0]),
+			Destination: fields[1],
+			Nexthop:     fields[2],
+		})
+	}
+	return routes, nil
+}
+
+// the nexthop of ecs, nat and peering should exist, vip is a private ip and vpn is the vpn gateway id
+func checkRouteTableRoute(param CloudProviderParam, route routeTableRoute) error {
+	validTypes := []string{ROUTE_TABLE_NEXTHOP_ECS, ROUTE_TABLE_NEXTHOP_VIP, ROUTE_TABLE_NEXTHOP_NAT, ROUTE_TABLE_NEXTHOP_PEERING, ROUTE_TABLE_NEXTHOP_VPN}
+	if err := isValidStringValue("route type", route.Type, validTypes); err != nil {
+		return err
+	}
+	ip, _, err := net.ParseCIDR(route.Destination)
+	if err != nil || ip.To4() == nil {
+		return fmt.Errorf("route destination(%v) is not a valid ipv4 cidr", route.Destination)
+	}
+	if route.Nexthop == "" {
+		return fmt.Errorf("route(%v) nexthop is empty", route.Destination)
+	}
+
+	switch route.Type {
+	case ROUTE_TABLE_NEXTHOP_VIP:
+		nexthop := net.ParseIP(route.Nexthop)
+		if nexthop == nil || nexthop.To4() == nil {
+			return fmt.Errorf("vip route nexthop(%v) is not a valid ipv4 address", route.Nexthop)
+		}
+		return nil
+	case ROUTE_TABLE_NEXTHOP_VPN:
+		if !routeTableNexthopIdRegexp.MatchString(route.Nexthop) {
+			return fmt.Errorf("vpn route nexthop(%v) is not a valid vpn gateway id", route.Nexthop)
+		}
+		return nil
+	}
+
+	exist := false
+	switch route.Type {
+	case ROUTE_TABLE_NEXTHOP_ECS:
+		_, exist, err = isVmExist(param, route.Nexthop)
+	case ROUTE_TABLE_NEXTHOP_NAT:
+		sc, clientErr := createNatServiceClient(param)
+		if clientErr != nil {
+			return clientErr
+		}
+		exist, err = isNatGatewayExist(sc, route.Nexthop)
+	case ROUTE_TABLE_NEXTHOP_PEERING:
+		sc, clientErr := createVpcServiceClientV2(param)
+		if clientErr != nil {
+			return clientErr
+		}
+		exist, err = isPeeringsExist(sc, route.Nexthop)
+	}
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return fmt.Errorf("%v route nexthop(%v) is not exist", route.Type, route.Nexthop)
+	}
+	return nil
+}
+
+func checkRouteTableRoutes(param CloudProviderParam, routes []routeTableRoute) error {
+	destinations := map[string]bool{}
+	for _, route := range routes {
+		if destinations[route.Destination] {
+			return fmt.Errorf("route destination(%v) is duplicated", route.Destination)
+		}
+		destinations[route.Destination] = true
+		if err := checkRouteTableRoute(param, route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//--------------create route table------------------//
+type RouteTableCreateInputs struct {
+	Inputs []RouteTableCreateInput `json:"inputs,omitempty"`
+}
+
+type RouteTableCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	VpcId       string `json:"vpc_id,omitempty"`
+	Description string `json:"description,omitempty"`
+	Routes      string `json:"routes,omitempty"`
+	SubnetIds   string `json:"subnet_ids,omitempty"`
+}
+
+type RouteTableCreateOutputs struct {
+	Outputs []RouteTableCreateOutput `json:"outputs,omitempty"`
+}
+
+type RouteTableCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type RouteTableCreateAction struct {
+}
+
+func (action *RouteTableCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RouteTableCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkRouteTableCreateParam(input RouteTableCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Name == "" {
+		return fmt.Errorf("name is empty")
+	}
+	if input.VpcId == "" {
+		return fmt.Errorf("vpcId is empty")
+	}
+	return nil
+}
+
+func createRouteTable(input RouteTableCreateInput) (output RouteTableCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkRouteTableCreateParam(input); err != nil {
+		return
+	}
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	if input.Id != "" {
+		_, exist, getErr := getRouteTableById(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	routes, err := parseRouteTableRoutes(input.Routes)
+	if err != nil {
+		return
+	}
+	if err = checkRouteTableRoutes(input.CloudProviderParam, routes); err != nil {
+		return
+	}
+	subnetIds, err := GetArrayFromString(input.SubnetIds, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+
+	body := map[string]interface{}{
+		"routetable": map[string]interface{}{
+			"name":        input.Name,
+			"vpc_id":      input.VpcId,
+			"description": input.Description,
+			"routes":      routes,
+		},
+	}
+	resp := struct {
+		RouteTable routeTable `json:"routetable"`
+	}{}
+	_, err = sc.Post(sc.ServiceURL("routetables"), body, &resp, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("create route table meet err=%v", err)
+		return
+	}
+
+	if err = updateRouteTableSubnets(sc, resp.RouteTable.Id, subnetIds, true); err != nil {
+		if _, deleteErr := sc.Delete(sc.ServiceURL("routetables", resp.RouteTable.Id), nil); deleteErr != nil {
+			err = fmt.Errorf("associate subnets meet err=%v && delete route table(%v) meet err=%v", err, resp.RouteTable.Id, deleteErr)
+		}
+		return
+	}
+	output.Id = resp.RouteTable.Id
+	return
+}
+
+func (action *RouteTableCreateAction) Do(inputs interface{}) (interface{}, error) {
+	tables, _ := inputs.(RouteTableCreateInputs)
+	outputs := RouteTableCreateOutputs{}
+	var finalErr error
+
+	for _, input := range tables.Inputs {
+		output, err := createRouteTable(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------delete route table------------------//
+type RouteTableDeleteInputs struct {
+	Inputs []RouteTableDeleteInput `json:"inputs,omitempty"`
+}
+
+type RouteTableDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type RouteTableDeleteOutputs struct {
+	Outputs []RouteTableDeleteOutput `json:"outputs,omitempty"`
+}
+
+type RouteTableDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type RouteTableDeleteAction struct {
+}
+
+func (action *RouteTableDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RouteTableDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteRouteTable(input RouteTableDeleteInput) (output RouteTableDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	table, exist, err := getRouteTableById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+	if table.Default {
+		err = fmt.Errorf("route table(%v) is the default route table of vpc(%v)", input.Id, table.VpcId)
+		return
+	}
+
+	// the disassociated subnets go back to the default route table
+	if err = updateRouteTableSubnets(sc, input.Id, table.getSubnetIds(), false); err != nil {
+		return
+	}
+	if _, err = sc.Delete(sc.ServiceURL("routetables", input.Id), nil); err != nil {
+		logrus.Errorf("delete route table(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *RouteTableDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	tables, _ := inputs.(RouteTableDeleteInputs)
+	outputs := RouteTableDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range tables.Inputs {
+		output, err := deleteRouteTable(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+//--------------associate and disassociate subnets------------------//
+type RouteTableSubnetInputs struct {
+	Inputs []RouteTableSubnetInput `json:"inputs,omitempty"`
+}
+
+type RouteTableSubnetInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	SubnetIds string `json:"subnet_ids,omitempty"`
+}
+
+type RouteTableSubnetOutputs struct {
+	Outputs []RouteTableSubnetOutput `json:"outputs,omitempty"`
+}
+
+type RouteTableSubnetOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type RouteTableAssociateAction struct {
+}
+
+func (action *RouteTableAssociateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RouteTableSubnetInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+type RouteTableDisassociateAction struct {
+}
+
+func (action *RouteTableDisassociateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs RouteTableSubnetInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func changeRouteTableSubnets(input RouteTableSubnetInput, isAssociate bool) (output RouteTableSubnetOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	subnetIds, err := GetArrayFromString(input.SubnetIds, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+	if len(subnetIds) == 0 {
+		err = fmt.Errorf("subnetIds is empty")
+		return
+	}
+
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	table, exist, err := getRouteTableById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("route table(%v) is not exist", input.Id)
+		return
+	}
+
+	// only change the subnets which are not in the expected state
+	associated := map[string]bool{}
+	for _, subnetId := range table.getSubnetIds() {
+		associated[subnetId] = true
+	}
+	changedSubnetIds := []string{}
+	for _, subnetId := range subnetIds {
+		if associated[subnetId] != isAssociate {
+			changedSubnetIds = append(changedSubnetIds, subnetId)
+		}
+	}
+	err = updateRouteTableSubnets(sc, input.Id, changedSubnetIds, isAssociate)
+	return
+}
+
+func (action *RouteTableAssociateAction) Do(inputs interface{}) (interface{}, error) {
+	tables, _ := inputs.(RouteTableSubnetInputs)
+	outputs := RouteTableSubnetOutputs{}
+	var finalErr error
+
+	for _, input := range tables.Inputs {
+		output, err := changeRouteTableSubnets(input, true)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+func (action *RouteTableDisassociateAction) Do(inputs interface{}) (interface{}, error) {
+	tables, _ := inputs.(RouteTableSubnetInputs)
+	outputs := RouteTableSubnetOutputs{}
+	var finalErr error
+
+	for _, input := range tables.Inputs {
+		output, err := changeRouteTableSubnets(input, false)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
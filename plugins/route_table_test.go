@@ -0,0 +1,62 @@
+package plugins
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRouteTableRoutes(t *testing.T) {
+	cases := []struct {
+		rawRoutes string
+		expected  []routeTableRoute
+		isErr     bool
+	}{
+		{"", []routeTableRoute{}, false},
+		{
+			"NAT 0.0.0.0/0 natId,vip 10.1.0.0/16 192.168.0.10",
+			[]routeTableRoute{
+				{Type: "nat", Destination: "0.0.0.0/0", Nexthop: "natId"},
+				{Type: "vip", Destination: "10.1.0.0/16", Nexthop: "192.168.0.10"},
+			},
+			false,
+		},
+		{"  peering   10.2.0.0/16   peeringId  ", []routeTableRoute{{Type: "peering", Destination: "10.2.0.0/16", Nexthop: "peeringId"}}, false},
+		{"nat 0.0.0.0/0", nil, true},
+		{"nat 0.0.0.0/0 natId extra", nil, true},
+	}
+
+	for _, c := range cases {
+		routes, err := parseRouteTableRoutes(c.rawRoutes)
+		if (err != nil) != c.isErr {
+			t.Errorf("parseRouteTableRoutes(%v) err=%v, expected err=%v", c.rawRoutes, err, c.isErr)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(routes, c.expected) {
+			t.Errorf("parseRouteTableRoutes(%v)=%++v, expected %++v", c.rawRoutes, routes, c.expected)
+		}
+	}
+}
+
+// only the routes which could be checked without the cloud
+func TestCheckRouteTableRoute(t *testing.T) {
+	cases := []struct {
+		route routeTableRoute
+		isErr bool
+	}{
+		{routeTableRoute{Type: "vip", Destination: "10.1.0.0/16", Nexthop: "192.168.0.10"}, false},
+		{routeTableRoute{Type: "vip", Destination: "10.1.0.0/16", Nexthop: "fd00::1"}, true},
+		{routeTableRoute{Type: "vip", Destination: "10.1.0.0/16", Nexthop: "vipId"}, true},
+		{routeTableRoute{Type: "vpn", Destination: "10.1.0.0/16", Nexthop: "0c3b5b45-3c4e-4f37-9a5c-8d5e3b8e2a11"}, false},
+		{routeTableRoute{Type: "vpn", Destination: "10.1.0.0/16", Nexthop: "vpnId"}, true},
+		{routeTableRoute{Type: "vpn", Destination: "fd00::/8", Nexthop: "0c3b5b45-3c4e-4f37-9a5c-8d5e3b8e2a11"}, true},
+		{routeTableRoute{Type: "vpn", Destination: "10.1.0.1", Nexthop: "0c3b5b45-3c4e-4f37-9a5c-8d5e3b8e2a11"}, true},
+		{routeTableRoute{Type: "igw", Destination: "0.0.0.0/0", Nexthop: "igwId"}, true},
+		{routeTableRoute{Type: "nat", Destination: "0.0.0.0/0", Nexthop: ""}, true},
+	}
+
+	for _, c := range cases {
+		if err := checkRouteTableRoute(CloudProviderParam{}, c.route); (err != nil) != c.isErr {
+			t.Errorf("checkRouteTableRoute(%++v) err=%v, expected err=%v", c.route, err, c.isErr)
+		}
+	}
+}
@@ -15,6 +15,13 @@ import (
 const (
 	RULE_DIRECTION_EGRESS  = "egress"
 	RULE_DIRECTION_INGRESS = "ingress"
+
+	RULE_PROTOCOL_ANY    = "any"
+	RULE_PROTOCOL_ICMP   = "icmp"
+	RULE_PROTOCOL_ICMPV6 = "icmpv6"
+
+	RULE_ETHERTYPE_IPV4 = "IPv4"
+	RULE_ETHERTYPE_IPV6 = "IPv6"
 )
 
 var securityGroupRuleActions = make(map[string]Action)
@@ -22,6 +29,7 @@ var securityGroupRuleActions = make(map[string]Action)
 func init() {
 	securityGroupRuleActions["create"] = new(SecurityGroupRuleCreateAction)
 	securityGroupRuleActions["delete"] = new(SecurityGroupRuleDeleteAction)
+	securityGroupRuleActions["sync"] = new(SecurityGroupRuleSyncAction)
 }
 
 type SecurityGroupRulePlugin struct {
@@ -50,6 +58,11 @@ type SecurityGroupRuleInput struct {
 	Protocol        string `json:"protocol,omitempty"`
 	Port            string `json:"port,omitempty"`
 	RemoteIpPrefix  string `json:"remote_ip_prefix,omitempty"`
+	RemoteGroupId   string `json:"remote_group_id,omitempty"`
+	Ethertype       string `json:"ethertype,omitempty"` //IPv4 or IPv6, inferred from remote_ip_prefix if empty
+	IcmpType        string `json:"icmp_type,omitempty"`
+	IcmpCode        string `json:"icmp_code,omitempty"`
+	Description     string `json:"description,omitempty"`
 }
 
 type SecurityGroupRuleCreateOutputs struct {
@@ -85,19 +98,10 @@ func checkRuleInputParams(input SecurityGroupRuleInput) error {
 
 	if input.Direction == "" {
 		return fmt.Errorf("Direction is empty")
-	} else {
-		input.Direction = strings.ToLower(input.Direction)
-		if input.Direction != RULE_DIRECTION_EGRESS && input.Direction != RULE_DIRECTION_INGRESS {
-			return fmt.Errorf("Direction is wrong")
-		}
 	}
 
-	if input.RemoteIpPrefix == "" {
-		return fmt.Errorf("RemoteIpPrefix is empty")
-	}
-
-	if input.Port == "" {
-		return fmt.Errorf("Port is empty")
+	if input.RemoteIpPrefix == "" && input.RemoteGroupId == "" {
+		return fmt.Errorf("RemoteIpPrefix and RemoteGroupId are both empty")
 	}
 
 	if input.Protocol == "" {
@@ -142,6 +146,198 @@ func getPortMinAndMax(port string) (int, int, error) {
 	return 0, 0, fmt.Errorf("port(%v) is unsupported port format", port)
 }
 
+// the normalized rule used to compare with the existing ones,
+// the port range is -1 if not set and holds the type and code for icmp
+type securityGroupRuleSpec struct {
+	Direction      string
+	Ethertype      string
+	Protocol       string
+	PortMin        int
+	PortMax        int
+	RemoteIpPrefix string
+	RemoteGroupId  string
+}
+
+func (rule securityGroupRuleSpec) String() string {
+	protocol := rule.Protocol
+	if protocol == "" {
+		protocol = RULE_PROTOCOL_ANY
+	}
+	port := "all"
+	if rule.PortMin >= 0 {
+		port = strconv.Itoa(rule.PortMin)
+		if rule.Protocol == RULE_PROTOCOL_ICMP || rule.Protocol == RULE_PROTOCOL_ICMPV6 {
+			if rule.PortMax >= 0 {
+				port = fmt.Sprintf("%v/%v", rule.PortMin, rule.PortMax)
+			}
+		} else if rule.PortMax != rule.PortMin {
+			port = fmt.Sprintf("%v-%v", rule.PortMin, rule.PortMax)
+		}
+	}
+	remote := rule.RemoteIpPrefix
+	if rule.RemoteGroupId != "" {
+		remote = "sg:" + rule.RemoteGroupId
+	}
+	return fmt.Sprintf("%v %v %v %v", rule.Direction, protocol, port, remote)
+}
+
+func newSecurityGroupRuleSpec(rule securitygrouprules.SecurityGroupRule) securityGroupRuleSpec {
+	spec := securityGroupRuleSpec{
+		Direction:      rule.Direction,
+		Ethertype:      rule.Ethertype,
+		Protocol:       strings.ToLower(rule.Protocol),
+		PortMin:        -1,
+		PortMax:        -1,
+		RemoteIpPrefix: rule.RemoteIpPrefix,
+		RemoteGroupId:  rule.RemoteGroupId,
+	}
+	if rule.PortRangeMin != nil {
+		spec.PortMin = *rule.PortRangeMin
+	}
+	if rule.PortRangeMax != nil {
+		spec.PortMax = *rule.PortRangeMax
+	}
+	// no port range of tcp or udp means all the ports, the same as the input ALL
+	isIcmp := spec.Protocol == RULE_PROTOCOL_ICMP || spec.Protocol == RULE_PROTOCOL_ICMPV6
+	if spec.Protocol != "" && !isIcmp && spec.PortMin < 0 && spec.PortMax < 0 {
+		spec.PortMin, spec.PortMax = 1, 65535
+	}
+	// no remote means all the addresses
+	if spec.RemoteIpPrefix == "" && spec.RemoteGroupId == "" {
+		spec.RemoteIpPrefix = "0.0.0.0/0"
+		if spec.Ethertype == RULE_ETHERTYPE_IPV6 {
+			spec.RemoteIpPrefix = "::/0"
+		}
+	}
+	return spec
+}
+
+func getSecurityGroupRuleSpec(input SecurityGroupRuleInput) (securityGroupRuleSpec, error) {
+	spec := securityGroupRuleSpec{
+		Direction:     strings.ToLower(strings.TrimSpace(input.Direction)),
+		Protocol:      strings.ToLower(strings.TrimSpace(input.Protocol)),
+		PortMin:       -1,
+		PortMax:       -1,
+		RemoteGroupId: strings.TrimSpace(input.RemoteGroupId),
+	}
+	if spec.Direction != RULE_DIRECTION_EGRESS && spec.Direction != RULE_DIRECTION_INGRESS {
+		return spec, fmt.Errorf("Direction(%v) is wrong", input.Direction)
+	}
+	if spec.Protocol == "" {
+		return spec, fmt.Errorf("Protocol is empty")
+	}
+
+	ip := strings.TrimSpace(input.RemoteIpPrefix)
+	if ip != "" && spec.RemoteGroupId != "" {
+		return spec, fmt.Errorf("RemoteIpPrefix(%v) and RemoteGroupId(%v) could not be both set", ip, spec.RemoteGroupId)
+	}
+	if ip == "" && spec.RemoteGroupId == "" {
+		return spec, fmt.Errorf("RemoteIpPrefix and RemoteGroupId are both empty")
+	}
+	if ip != "" {
+		if !strings.Contains(ip, "/") {
+			if strings.Contains(ip, ":") {
+				ip = ip + "/128"
+			} else {
+				ip = ip + "/32"
+			}
+		}
+		if err := isValidCidr(ip); err != nil {
+			return spec, err
+		}
+	}
+	spec.RemoteIpPrefix = ip
+
+	spec.Ethertype = RULE_ETHERTYPE_IPV4
+	if strings.Contains(ip, ":") {
+		spec.Ethertype = RULE_ETHERTYPE_IPV6
+	}
+	if ethertype := strings.TrimSpace(input.Ethertype); ethertype != "" {
+		if strings.EqualFold(ethertype, RULE_ETHERTYPE_IPV4) {
+			ethertype = RULE_ETHERTYPE_IPV4
+		} else if strings.EqualFold(ethertype, RULE_ETHERTYPE_IPV6) {
+			ethertype = RULE_ETHERTYPE_IPV6
+		} else {
+			return spec, fmt.Errorf("Ethertype(%v) is wrong", input.Ethertype)
+		}
+		if ip != "" && ethertype != spec.Ethertype {
+			return spec, fmt.Errorf("Ethertype(%v) is not matched with RemoteIpPrefix(%v)", ethertype, ip)
+		}
+		spec.Ethertype = ethertype
+	}
+
+	icmpType := strings.TrimSpace(input.IcmpType)
+	icmpCode := strings.TrimSpace(input.IcmpCode)
+	switch spec.Protocol {
+	case RULE_PROTOCOL_ANY:
+		spec.Protocol = ""
+		if port := strings.TrimSpace(input.Port); port != "" && !strings.EqualFold(port, "ALL") {
+			return spec, fmt.Errorf("Port(%v) is not supported by protocol any", port)
+		}
+	case RULE_PROTOCOL_ICMP, RULE_PROTOCOL_ICMPV6:
+		if spec.Ethertype == RULE_ETHERTYPE_IPV6 {
+			spec.Protocol = RULE_PROTOCOL_ICMPV6
+		} else if spec.Protocol == RULE_PROTOCOL_ICMPV6 {
+			return spec, fmt.Errorf("Protocol icmpv6 is only supported by IPv6")
+		}
+		if icmpType != "" {
+			value, err := isValidInteger(icmpType, 0, 255)
+			if err != nil {
+				return spec, fmt.Errorf("IcmpType(%v) is invalid", icmpType)
+			}
+			spec.PortMin = int(value)
+		}
+		if icmpCode != "" {
+			if icmpType == "" {
+				return spec, fmt.Errorf("IcmpCode should be set with IcmpType")
+			}
+			value, err := isValidInteger(icmpCode, 0, 255)
+			if err != nil {
+				return spec, fmt.Errorf("IcmpCode(%v) is invalid", icmpCode)
+			}
+			spec.PortMax = int(value)
+		}
+	default:
+		if icmpType != "" || icmpCode != "" {
+			return spec, fmt.Errorf("IcmpType and IcmpCode are only supported by protocol icmp")
+		}
+		if strings.TrimSpace(input.Port) == "" {
+			return spec, fmt.Errorf("Port is empty")
+		}
+		portMin, portMax, err := getPortMinAndMax(input.Port)
+		if err != nil {
+			return spec, err
+		}
+		spec.PortMin, spec.PortMax = portMin, portMax
+	}
+	return spec, nil
+}
+
+func createSecurityGroupRule(sc *gophercloud.ServiceClient, securityGroupId string, spec securityGroupRuleSpec, description string) (string, error) {
+	request := securitygrouprules.CreateOpts{
+		SecurityGroupId: securityGroupId,
+		Description:     description,
+		Direction:       spec.Direction,
+		Ethertype:       spec.Ethertype,
+		Protocol:        spec.Protocol,
+		RemoteIpPrefix:  spec.RemoteIpPrefix,
+		RemoteGroupId:   spec.RemoteGroupId,
+	}
+	if spec.PortMin >= 0 {
+		portMin := spec.PortMin
+		request.PortRangeMin = &portMin
+	}
+	if spec.PortMax >= 0 {
+		portMax := spec.PortMax
+		request.PortRangeMax = &portMax
+	}
+	resp, err := securitygrouprules.Create(sc, request).Extract()
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
 func createRule(input *SecurityGroupRuleInput) (output SecurityGroupRuleCreateOutput, err error) {
 	defer func() {
 		output.Guid = input.Guid
@@ -164,6 +360,18 @@ func createRule(input *SecurityGroupRuleInput) (output SecurityGroupRuleCreateOu
 		return
 	}
 
+	specs := []securityGroupRuleSpec{}
+	for _, newInput := range newInputs {
+		var spec securityGroupRuleSpec
+		if spec, err = getSecurityGroupRuleSpec(newInput); err != nil {
+			return
+		}
+		specs = append(specs, spec)
+	}
+	if err = checkSecurityGroupRulePolicy(specs); err != nil {
+		return
+	}
+
 	// create vpc service client
 	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
 	if err != nil {
@@ -171,25 +379,9 @@ func createRule(input *SecurityGroupRuleInput) (output SecurityGroupRuleCreateOu
 	}
 
 	newCreateRules := []SecurityGroupRuleInput{}
-	for _, newInput := range newInputs {
-		var portMin, portMax int
-
+	for i, newInput := range newInputs {
 		// create security group rule
-		request := securitygrouprules.CreateOpts{
-			SecurityGroupId: newInput.SecurityGroupId,
-			Direction:       strings.ToLower(newInput.Direction),
-		}
-
-		portMin, portMax, err = getPortMinAndMax(newInput.Port)
-		if err != nil {
-			break
-		}
-
-		request.PortRangeMin = &portMin
-		request.PortRangeMax = &portMax
-		request.Protocol = strings.ToLower(newInput.Protocol)
-		request.RemoteIpPrefix = newInput.RemoteIpPrefix
-		_, err = securitygrouprules.Create(sc, request).Extract()
+		_, err = createSecurityGroupRule(sc, newInput.SecurityGroupId, specs[i], newInput.Description)
 
 		if err != nil {
 			if ue, ok := err.(*gophercloud.UnifiedError); ok {
@@ -219,34 +411,74 @@ func createRule(input *SecurityGroupRuleInput) (output SecurityGroupRuleCreateOu
 	return
 }
 
+func getRuleArray(rawData string, expectedLen int) ([]string, error) {
+	if rawData == "" {
+		return make([]string, expectedLen), nil
+	}
+	return GetArrayFromString(rawData, ARRAY_SIZE_AS_EXPECTED, expectedLen)
+}
+
+// one rule for each remote ip prefix and remote security group,
+// the other fields are lists of the same size or a single value for all the rules,
+// except the description which is free text and shared by all the rules
 func extractSecurityGroupRules(input SecurityGroupRuleInput) (newInputs []SecurityGroupRuleInput, err error) {
 	ruleIps, err := GetArrayFromString(input.RemoteIpPrefix, ARRAY_SIZE_REAL, 0)
 	if err != nil {
 		return
 	}
 
-	ports, err := GetArrayFromString(input.Port, ARRAY_SIZE_AS_EXPECTED, len(ruleIps))
+	remoteGroupIds, err := GetArrayFromString(input.RemoteGroupId, ARRAY_SIZE_REAL, 0)
 	if err != nil {
 		return
 	}
+	ruleCount := len(ruleIps) + len(remoteGroupIds)
 
-	protocols, err := GetArrayFromString(input.Protocol, ARRAY_SIZE_AS_EXPECTED, len(ruleIps))
+	directions, err := getRuleArray(input.Direction, ruleCount)
 	if err != nil {
 		return
 	}
 
-	for i, ip := range ruleIps {
+	ports, err := getRuleArray(input.Port, ruleCount)
+	if err != nil {
+		return
+	}
+
+	protocols, err := getRuleArray(input.Protocol, ruleCount)
+	if err != nil {
+		return
+	}
+
+	ethertypes, err := getRuleArray(input.Ethertype, ruleCount)
+	if err != nil {
+		return
+	}
+
+	icmpTypes, err := getRuleArray(input.IcmpType, ruleCount)
+	if err != nil {
+		return
+	}
+
+	icmpCodes, err := getRuleArray(input.IcmpCode, ruleCount)
+	if err != nil {
+		return
+	}
+
+	for i := 0; i < ruleCount; i++ {
 		rule := SecurityGroupRuleInput{
 			Port:               ports[i],
 			Protocol:           strings.ToLower(protocols[i]),
-			Direction:          strings.ToLower(input.Direction),
+			Direction:          strings.ToLower(directions[i]),
+			Ethertype:          ethertypes[i],
+			IcmpType:           icmpTypes[i],
+			IcmpCode:           icmpCodes[i],
+			Description:        input.Description,
 			SecurityGroupId:    input.SecurityGroupId,
 			CloudProviderParam: input.CloudProviderParam,
 		}
-		if !strings.Contains(ip, "/") {
-			rule.RemoteIpPrefix = ip + "/32"
+		if i < len(ruleIps) {
+			rule.RemoteIpPrefix = ruleIps[i]
 		} else {
-			rule.RemoteIpPrefix = ip
+			rule.RemoteGroupId = remoteGroupIds[i-len(ruleIps)]
 		}
 		newInputs = append(newInputs, rule)
 	}
@@ -372,21 +604,12 @@ func getSecurityGroupRule(sc *gophercloud.ServiceClient, inputs []SecurityGroupR
 
 	ruleIdMap := map[string]int{}
 	for _, input := range inputs {
-		minPort, maxPort, err := getPortMinAndMax(input.Port)
+		spec, err := getSecurityGroupRuleSpec(input)
 		if err != nil {
 			return []string{}, err
 		}
 		for _, rule := range resp {
-			if rule.Protocol != input.Protocol {
-				continue
-			}
-			if *rule.PortRangeMax != maxPort || *rule.PortRangeMin != minPort {
-				continue
-			}
-			if rule.Direction != input.Direction {
-				continue
-			}
-			if rule.RemoteIpPrefix != input.RemoteIpPrefix {
+			if newSecurityGroupRuleSpec(rule) != spec {
 				continue
 			}
 
@@ -472,3 +695,168 @@ func (action *SecurityGroupRuleDeleteAction) Do(inputs interface{}) (interface{}
 	logrus.Infof("all securitygroup rules = %v are deleted", rules)
 	return &outputs, finalErr
 }
+
+//--------------sync security group rules------------------//
+// the desired rules are expanded the same way as create
+type SecurityGroupRuleSyncInputs struct {
+	Inputs []SecurityGroupRuleInput `json:"inputs,omitempty"`
+}
+
+type SecurityGroupRuleSyncOutputs struct {
+	Outputs []SecurityGroupRuleSyncOutput `json:"outputs,omitempty"`
+}
+
+type SecurityGroupRuleSyncOutput struct {
+	CallBackParameter
+	Result
+	Guid         string `json:"guid,omitempty"`
+	AddedRules   string `json:"added_rules,omitempty"`
+	RemovedRules string `json:"removed_rules,omitempty"`
+}
+
+type SecurityGroupRuleSyncAction struct {
+}
+
+func (action *SecurityGroupRuleSyncAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs SecurityGroupRuleSyncInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// create the missing rules first and then remove the extra ones,
+// the applied changes are reverted if any step fails
+func syncRule(input *SecurityGroupRuleInput) (output SecurityGroupRuleSyncOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkRuleInputParams(*input); err != nil {
+		logrus.Errorf("checkSyncRuleParams meet error=%v", err)
+		return
+	}
+
+	newInputs, err := extractSecurityGroupRules(*input)
+	if err != nil {
+		return
+	}
+	desiredRules := []securityGroupRuleSpec{}
+	descriptions := map[securityGroupRuleSpec]string{}
+	for _, newInput := range newInputs {
+		var spec securityGroupRuleSpec
+		if spec, err = getSecurityGroupRuleSpec(newInput); err != nil {
+			return
+		}
+		desiredRules = append(desiredRules, spec)
+		descriptions[spec] = newInput.Description
+	}
+	if err = checkSecurityGroupRulePolicy(desiredRules); err != nil {
+		return
+	}
+
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	allPages, err := securitygrouprules.List(sc, securitygrouprules.ListOpts{SecurityGroupId: input.SecurityGroupId}).AllPages()
+	if err != nil {
+		return
+	}
+	currentRules, err := securitygrouprules.ExtractSecurityGroupRules(allPages)
+	if err != nil {
+		return
+	}
+
+	currentMap := map[securityGroupRuleSpec]bool{}
+	for _, rule := range currentRules {
+		currentMap[newSecurityGroupRuleSpec(rule)] = true
+	}
+	desiredMap := map[securityGroupRuleSpec]bool{}
+	addRules := []securityGroupRuleSpec{}
+	for _, rule := range desiredRules {
+		if desiredMap[rule] {
+			continue
+		}
+		desiredMap[rule] = true
+		if !currentMap[rule] {
+			addRules = append(addRules, rule)
+		}
+	}
+	removeRules := []securitygrouprules.SecurityGroupRule{}
+	for _, rule := range currentRules {
+		if !desiredMap[newSecurityGroupRuleSpec(rule)] {
+			removeRules = append(removeRules, rule)
+		}
+	}
+
+	createdRuleIds := []string{}
+	removedRules := []securitygrouprules.SecurityGroupRule{}
+	defer func() {
+		if err == nil {
+			return
+		}
+		logrus.Infof("roll back security group(%v) sync, created=%v removed=%v", input.SecurityGroupId, createdRuleIds, len(removedRules))
+		for _, ruleId := range createdRuleIds {
+			if deleteErr := securitygrouprules.Delete(sc, ruleId).Err; deleteErr != nil {
+				err = fmt.Errorf("sync rule meet error=%v && roll back created rule(%v) meet error=%v", err, ruleId, deleteErr)
+				return
+			}
+		}
+		for _, rule := range removedRules {
+			if _, createErr := createSecurityGroupRule(sc, input.SecurityGroupId, newSecurityGroupRuleSpec(rule), rule.Description); createErr != nil {
+				err = fmt.Errorf("sync rule meet error=%v && roll back removed rule(%v) meet error=%v", err, rule.ID, createErr)
+				return
+			}
+		}
+	}()
+
+	addedRules := []string{}
+	for _, rule := range addRules {
+		var ruleId string
+		if ruleId, err = createSecurityGroupRule(sc, input.SecurityGroupId, rule, descriptions[rule]); err != nil {
+			logrus.Errorf("create security group(%v) rule(%v) meet error=%v", input.SecurityGroupId, rule, err)
+			return
+		}
+		createdRuleIds = append(createdRuleIds, ruleId)
+		addedRules = append(addedRules, rule.String())
+	}
+
+	deletedRules := []string{}
+	for _, rule := range removeRules {
+		if err = securitygrouprules.Delete(sc, rule.ID).Err; err != nil {
+			logrus.Errorf("delete security group rule(%v) meet error=%v", rule.ID, err)
+			return
+		}
+		removedRules = append(removedRules, rule)
+		deletedRules = append(deletedRules, newSecurityGroupRuleSpec(rule).String())
+	}
+
+	output.AddedRules = strings.Join(addedRules, ",")
+	output.RemovedRules = strings.Join(deletedRules, ",")
+	return
+}
+
+func (action *SecurityGroupRuleSyncAction) Do(inputs interface{}) (interface{}, error) {
+	rules, _ := inputs.(SecurityGroupRuleSyncInputs)
+	outputs := SecurityGroupRuleSyncOutputs{}
+	var finalErr error
+	for _, rule := range rules.Inputs {
+		output, err := syncRule(&rule)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all securitygroup rules = %v are synced", rules)
+	return &outputs, finalErr
+}
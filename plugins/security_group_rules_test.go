@@ -0,0 +1,142 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/vpc/v1/securitygrouprules"
+)
+
+func TestGetSecurityGroupRuleSpec(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    SecurityGroupRuleInput
+		expected string
+		isErr    bool
+	}{
+		{"single port", SecurityGroupRuleInput{Direction: "ingress", Protocol: "TCP", Port: "80", RemoteIpPrefix: "10.0.0.0/8"}, "ingress tcp 80 10.0.0.0/8", false},
+		{"port range", SecurityGroupRuleInput{Direction: "egress", Protocol: "udp", Port: "8000-8010", RemoteIpPrefix: "10.0.0.1"}, "egress udp 8000-8010 10.0.0.1/32", false},
+		{"all ports", SecurityGroupRuleInput{Direction: "ingress", Protocol: "tcp", Port: "ALL", RemoteIpPrefix: "0.0.0.0/0"}, "ingress tcp 1-65535 0.0.0.0/0", false},
+		{"any protocol", SecurityGroupRuleInput{Direction: "ingress", Protocol: "any", RemoteIpPrefix: "10.0.0.0/8"}, "ingress any all 10.0.0.0/8", false},
+		{"any protocol with port", SecurityGroupRuleInput{Direction: "ingress", Protocol: "any", Port: "80", RemoteIpPrefix: "10.0.0.0/8"}, "", true},
+		{"icmp type and code", SecurityGroupRuleInput{Direction: "ingress", Protocol: "icmp", IcmpType: "8", IcmpCode: "0", RemoteIpPrefix: "10.0.0.0/8"}, "ingress icmp 8/0 10.0.0.0/8", false},
+		{"icmp code without type", SecurityGroupRuleInput{Direction: "ingress", Protocol: "icmp", IcmpCode: "0", RemoteIpPrefix: "10.0.0.0/8"}, "", true},
+		{"icmp of ipv6", SecurityGroupRuleInput{Direction: "ingress", Protocol: "icmp", RemoteIpPrefix: "fd00::/8"}, "ingress icmpv6 all fd00::/8", false},
+		{"icmpv6 of ipv4", SecurityGroupRuleInput{Direction: "ingress", Protocol: "icmpv6", RemoteIpPrefix: "10.0.0.0/8"}, "", true},
+		{"icmp type of tcp", SecurityGroupRuleInput{Direction: "ingress", Protocol: "tcp", Port: "80", IcmpType: "8", RemoteIpPrefix: "10.0.0.0/8"}, "", true},
+		{"remote group", SecurityGroupRuleInput{Direction: "ingress", Protocol: "tcp", Port: "22", RemoteGroupId: "sg-1"}, "ingress tcp 22 sg:sg-1", false},
+		{"remote ip and group", SecurityGroupRuleInput{Direction: "ingress", Protocol: "tcp", Port: "22", RemoteIpPrefix: "10.0.0.0/8", RemoteGroupId: "sg-1"}, "", true},
+		{"no remote", SecurityGroupRuleInput{Direction: "ingress", Protocol: "tcp", Port: "22"}, "", true},
+		{"ethertype not matched", SecurityGroupRuleInput{Direction: "ingress", Protocol: "tcp", Port: "22", RemoteIpPrefix: "10.0.0.0/8", Ethertype: "IPv6"}, "", true},
+		{"wrong direction", SecurityGroupRuleInput{Direction: "in", Protocol: "tcp", Port: "22", RemoteIpPrefix: "10.0.0.0/8"}, "", true},
+		{"empty port", SecurityGroupRuleInput{Direction: "ingress", Protocol: "tcp", RemoteIpPrefix: "10.0.0.0/8"}, "", true},
+	}
+
+	for _, c := range cases {
+		spec, err := getSecurityGroupRuleSpec(c.input)
+		if (err != nil) != c.isErr {
+			t.Errorf("%v: err=%v, expected err=%v", c.name, err, c.isErr)
+			continue
+		}
+		if err == nil && spec.String() != c.expected {
+			t.Errorf("%v: spec=%v, expected %v", c.name, spec, c.expected)
+		}
+	}
+}
+
+func TestNewSecurityGroupRuleSpec(t *testing.T) {
+	port80, icmpType, portMin, portMax := 80, 8, 1, 65535
+	cases := []struct {
+		name     string
+		rule     securitygrouprules.SecurityGroupRule
+		input    SecurityGroupRuleInput
+		expected string
+	}{
+		{
+			"tcp port",
+			securitygrouprules.SecurityGroupRule{Direction: "ingress", Ethertype: "IPv4", Protocol: "tcp", PortRangeMin: &port80, PortRangeMax: &port80, RemoteIpPrefix: "10.0.0.0/8"},
+			SecurityGroupRuleInput{Direction: "ingress", Protocol: "tcp", Port: "80", RemoteIpPrefix: "10.0.0.0/8"},
+			"ingress tcp 80 10.0.0.0/8",
+		},
+		{
+			"no remote means all ipv4",
+			securitygrouprules.SecurityGroupRule{Direction: "egress", Ethertype: "IPv4"},
+			SecurityGroupRuleInput{Direction: "egress", Protocol: "any", RemoteIpPrefix: "0.0.0.0/0"},
+			"egress any all 0.0.0.0/0",
+		},
+		{
+			"no remote means all ipv6",
+			securitygrouprules.SecurityGroupRule{Direction: "egress", Ethertype: "IPv6"},
+			SecurityGroupRuleInput{Direction: "egress", Protocol: "any", RemoteIpPrefix: "::/0"},
+			"egress any all ::/0",
+		},
+		{
+			"no port range means all ports",
+			securitygrouprules.SecurityGroupRule{Direction: "ingress", Ethertype: "IPv4", Protocol: "tcp", RemoteIpPrefix: "10.0.0.0/8"},
+			SecurityGroupRuleInput{Direction: "ingress", Protocol: "tcp", Port: "ALL", RemoteIpPrefix: "10.0.0.0/8"},
+			"ingress tcp 1-65535 10.0.0.0/8",
+		},
+		{
+			"full port range is all ports",
+			securitygrouprules.SecurityGroupRule{Direction: "ingress", Ethertype: "IPv4", Protocol: "udp", PortRangeMin: &portMin, PortRangeMax: &portMax, RemoteIpPrefix: "10.0.0.0/8"},
+			SecurityGroupRuleInput{Direction: "ingress", Protocol: "udp", Port: "all", RemoteIpPrefix: "10.0.0.0/8"},
+			"ingress udp 1-65535 10.0.0.0/8",
+		},
+		{
+			"icmp type only",
+			securitygrouprules.SecurityGroupRule{Direction: "ingress", Ethertype: "IPv4", Protocol: "ICMP", PortRangeMin: &icmpType, RemoteGroupId: "sg-1"},
+			SecurityGroupRuleInput{Direction: "ingress", Protocol: "icmp", IcmpType: "8", RemoteGroupId: "sg-1"},
+			"ingress icmp 8 sg:sg-1",
+		},
+	}
+
+	for _, c := range cases {
+		spec := newSecurityGroupRuleSpec(c.rule)
+		if spec.String() != c.expected {
+			t.Errorf("%v: spec=%v, expected %v", c.name, spec, c.expected)
+		}
+		// the existing rule should be the same as the one from the input
+		inputSpec, err := getSecurityGroupRuleSpec(c.input)
+		if err != nil {
+			t.Errorf("%v: getSecurityGroupRuleSpec meet err=%v", c.name, err)
+			continue
+		}
+		if inputSpec != spec {
+			t.Errorf("%v: existing spec=%++v, input spec=%++v", c.name, spec, inputSpec)
+		}
+	}
+}
+
+func TestExtractSecurityGroupRules(t *testing.T) {
+	input := SecurityGroupRuleInput{
+		Direction:      "ingress",
+		Protocol:       "tcp,udp,tcp",
+		Port:           "80",
+		RemoteIpPrefix: "10.0.0.0/8,192.168.0.0/16",
+		RemoteGroupId:  "sg-1",
+		Description:    "web, api",
+	}
+	rules, err := extractSecurityGroupRules(input)
+	if err != nil {
+		t.Fatalf("extractSecurityGroupRules meet err=%v", err)
+	}
+	if len(rules) != 3 {
+		t.Fatalf("extractSecurityGroupRules got %v rules, expected 3", len(rules))
+	}
+	expected := []SecurityGroupRuleInput{
+		{Direction: "ingress", Protocol: "tcp", Port: "80", RemoteIpPrefix: "10.0.0.0/8", Description: "web, api"},
+		{Direction: "ingress", Protocol: "udp", Port: "80", RemoteIpPrefix: "192.168.0.0/16", Description: "web, api"},
+		{Direction: "ingress", Protocol: "tcp", Port: "80", RemoteGroupId: "sg-1", Description: "web, api"},
+	}
+	for i, rule := range rules {
+		if rule.Direction != expected[i].Direction || rule.Protocol != expected[i].Protocol || rule.Port != expected[i].Port ||
+			rule.RemoteIpPrefix != expected[i].RemoteIpPrefix || rule.RemoteGroupId != expected[i].RemoteGroupId ||
+			rule.Description != expected[i].Description {
+			t.Errorf("rule[%v]=%++v, expected %++v", i, rule, expected[i])
+		}
+	}
+
+	input.Protocol = "tcp,udp"
+	if _, err = extractSecurityGroupRules(input); err == nil {
+		t.Errorf("extractSecurityGroupRules with mismatched protocols should meet err")
+	}
+}
@@ -0,0 +1,560 @@
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/vpc/v1/bandwidths"
+	bandwidthsv2 "github.com/gophercloud/gophercloud/openstack/vpc/v2.0/bandwidths"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	SHARED_BANDWIDTH_SIZE_MIN = 5
+	SHARED_BANDWIDTH_SIZE_MAX = 2000
+)
+
+var sharedBandwidthActions = make(map[string]Action)
+
+func init() {
+	sharedBandwidthActions["create"] = new(SharedBandwidthCreateAction)
+	sharedBandwidthActions["delete"] = new(SharedBandwidthDeleteAction)
+	sharedBandwidthActions["resize"] = new(SharedBandwidthResizeAction)
+	sharedBandwidthActions["add-eips"] = new(SharedBandwidthAddEipsAction)
+	sharedBandwidthActions["remove-eips"] = new(SharedBandwidthRemoveEipsAction)
+}
+
+type SharedBandwidthPlugin struct {
+}
+
+func (plugin *SharedBandwidthPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := sharedBandwidthActions[actionName]
+	if !found {
+		logrus.Errorf("shared-bandwidth plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("shared-bandwidth plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+func getSharedBandwidthById(sc *gophercloud.ServiceClient, id string) (*bandwidths.BandWidth, bool, error) {
+	bandwidth, err := bandwidths.Get(sc, id).Extract()
+	if err != nil {
+		if ue, ok := err.(*gophercloud.UnifiedError); ok {
+			if strings.Contains(ue.Message(), "could not be found") || strings.Contains(ue.Message(), "not exist") {
+				return nil, false, nil
+			}
+		}
+		logrus.Errorf("get shared bandwidth(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	if bandwidth.ShareType != BANDWIDTH_SHARE_TYPE_WHOLE {
+		return nil, false, fmt.Errorf("bandwidth(%v) is not a shared bandwidth", id)
+	}
+	return bandwidth, true, nil
+}
+
+func getSharedBandwidthPublicIpIds(bandwidth *bandwidths.BandWidth) []string {
+	publicIpIds := []string{}
+	for _, publicIp := range bandwidth.PublicipInfo {
+		publicIpIds = append(publicIpIds, publicIp.PublicipId)
+	}
+	return publicIpIds
+}
+
+//--------------create shared bandwidth------------------//
+type SharedBandwidthCreateInputs struct {
+	Inputs []SharedBandwidthCreateInput `json:"inputs,omitempty"`
+}
+
+type SharedBandwidthCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid                string `json:"guid,omitempty"`
+	Id                  string `json:"id,omitempty"`
+	Name                string `json:"name,omitempty"`
+	BandWidth           string `json:"band_width,omitempty"`
+	EnterpriseProjectId string `json:"enterprise_project_id,omitempty"`
+}
+
+type SharedBandwidthCreateOutputs struct {
+	Outputs []SharedBandwidthCreateOutput `json:"outputs,omitempty"`
+}
+
+type SharedBandwidthCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type SharedBandwidthCreateAction struct {
+}
+
+func (action *SharedBandwidthCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs SharedBandwidthCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkSharedBandwidthCreateParam(input SharedBandwidthCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Name == "" {
+		return fmt.Errorf("name is empty")
+	}
+	if _, err := isValidInteger(input.BandWidth, SHARED_BANDWIDTH_SIZE_MIN, SHARED_BANDWIDTH_SIZE_MAX); err != nil {
+		return fmt.Errorf("bandWidth is invalid, %v", err)
+	}
+	return nil
+}
+
+func createSharedBandwidth(input SharedBandwidthCreateInput) (output SharedBandwidthCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkSharedBandwidthCreateParam(input); err != nil {
+		return
+	}
+	if input.Id != "" {
+		sc, clientErr := CreateVpcServiceClientV1(input.CloudProviderParam)
+		if clientErr != nil {
+			err = clientErr
+			return
+		}
+		_, exist, getErr := getSharedBandwidthById(sc, input.Id)
+		if getErr == nil && exist {
+			output.Id = input.Id
+			return
+		}
+	}
+
+	sc, err := createVpcServiceClientV2(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	size, _ := strconv.Atoi(input.BandWidth)
+	bandwidth, err := bandwidthsv2.Create(sc, bandwidthsv2.CreateOpts{
+		Name:                input.Name,
+		Size:                &size,
+		EnterpriseProjectId: input.EnterpriseProjectId,
+	}).Extract()
+	if err != nil {
+		logrus.Errorf("create shared bandwidth meet err=%v", err)
+		return
+	}
+	output.Id = bandwidth.ID
+	return
+}
+
+func (action *SharedBandwidthCreateAction) Do(inputs interface{}) (interface{}, error) {
+	bandwidths, _ := inputs.(SharedBandwidthCreateInputs)
+	outputs := SharedBandwidthCreateOutputs{}
+	var finalErr error
+
+	for _, input := range bandwidths.Inputs {
+		output, err := createSharedBandwidth(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all shared bandwidths = %v are created", bandwidths)
+	return &outputs, finalErr
+}
+
+//--------------delete shared bandwidth------------------//
+type SharedBandwidthDeleteInputs struct {
+	Inputs []SharedBandwidthDeleteInput `json:"inputs,omitempty"`
+}
+
+type SharedBandwidthDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type SharedBandwidthDeleteOutputs struct {
+	Outputs []SharedBandwidthDeleteOutput `json:"outputs,omitempty"`
+}
+
+type SharedBandwidthDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type SharedBandwidthDeleteAction struct {
+}
+
+func (action *SharedBandwidthDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs SharedBandwidthDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteSharedBandwidth(input SharedBandwidthDeleteInput) (output SharedBandwidthDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	bandwidth, exist, err := getSharedBandwidthById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	// the removed public ips need their own bandwidth size, so it's not done implicitly
+	if publicIpIds := getSharedBandwidthPublicIpIds(bandwidth); len(publicIpIds) > 0 {
+		err = fmt.Errorf("shared bandwidth(%v) still has public ips(%v), remove them first", input.Id, publicIpIds)
+		return
+	}
+
+	scV2, err := createVpcServiceClientV2(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	if err = bandwidthsv2.Delete(scV2, input.Id).ExtractErr(); err != nil {
+		logrus.Errorf("delete shared bandwidth(%v) meet err=%v", input.Id, err)
+	}
+	return
+}
+
+func (action *SharedBandwidthDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	bandwidths, _ := inputs.(SharedBandwidthDeleteInputs)
+	outputs := SharedBandwidthDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range bandwidths.Inputs {
+		output, err := deleteSharedBandwidth(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all shared bandwidths = %v are deleted", bandwidths)
+	return &outputs, finalErr
+}
+
+//--------------resize shared bandwidth------------------//
+type SharedBandwidthResizeInputs struct {
+	Inputs []SharedBandwidthResizeInput `json:"inputs,omitempty"`
+}
+
+type SharedBandwidthResizeInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	BandWidth string `json:"band_width,omitempty"`
+}
+
+type SharedBandwidthResizeOutputs struct {
+	Outputs []SharedBandwidthResizeOutput `json:"outputs,omitempty"`
+}
+
+type SharedBandwidthResizeOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type SharedBandwidthResizeAction struct {
+}
+
+func (action *SharedBandwidthResizeAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs SharedBandwidthResizeInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func resizeSharedBandwidth(input SharedBandwidthResizeInput) (output SharedBandwidthResizeOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	size, err := isValidInteger(input.BandWidth, SHARED_BANDWIDTH_SIZE_MIN, SHARED_BANDWIDTH_SIZE_MAX)
+	if err != nil {
+		err = fmt.Errorf("bandWidth is invalid, %v", err)
+		return
+	}
+
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	_, exist, err := getSharedBandwidthById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("shared bandwidth(%v) is not exist", input.Id)
+		return
+	}
+	err = updateBandwidth(input.CloudProviderParam, input.Id, int(size), "")
+	return
+}
+
+func (action *SharedBandwidthResizeAction) Do(inputs interface{}) (interface{}, error) {
+	bandwidths, _ := inputs.(SharedBandwidthResizeInputs)
+	outputs := SharedBandwidthResizeOutputs{}
+	var finalErr error
+
+	for _, input := range bandwidths.Inputs {
+		output, err := resizeSharedBandwidth(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all shared bandwidths = %v are resized", bandwidths)
+	return &outputs, finalErr
+}
+
+//--------------add and remove public ips------------------//
+type SharedBandwidthEipsInputs struct {
+	Inputs []SharedBandwidthEipsInput `json:"inputs,omitempty"`
+}
+
+type SharedBandwidthEipsInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	PublicIpIds string `json:"public_ip_ids,omitempty"`
+
+	// the dedicated bandwidth of the removed public ips
+	BandWidth  string `json:"band_width,omitempty"`
+	ChargeMode string `json:"charge_mode,omitempty"`
+}
+
+type SharedBandwidthEipsOutputs struct {
+	Outputs []SharedBandwidthEipsOutput `json:"outputs,omitempty"`
+}
+
+type SharedBandwidthEipsOutput struct {
+	CallBackParameter
+	Result
+	Guid        string `json:"guid,omitempty"`
+	Id          string `json:"id,omitempty"`
+	PublicIpIds string `json:"public_ip_ids,omitempty"`
+}
+
+type SharedBandwidthAddEipsAction struct {
+}
+
+func (action *SharedBandwidthAddEipsAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs SharedBandwidthEipsInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+type SharedBandwidthRemoveEipsAction struct {
+}
+
+func (action *SharedBandwidthRemoveEipsAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs SharedBandwidthEipsInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkSharedBandwidthEipsParam(input SharedBandwidthEipsInput, isAdd bool) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.Id == "" {
+		return fmt.Errorf("id is empty")
+	}
+	if input.PublicIpIds == "" {
+		return fmt.Errorf("publicIpIds is empty")
+	}
+	if isAdd {
+		return nil
+	}
+	if _, err := isValidInteger(input.BandWidth, BANDWIDTH_SIZE_MIN, BANDWIDTH_SIZE_MAX); err != nil {
+		return fmt.Errorf("bandWidth is invalid, %v", err)
+	}
+	if input.ChargeMode != "" {
+		if err := isValidStringValue("charge_mode", input.ChargeMode, []string{BANDWIDTH_CHARGE_MODE_BANDWIDTH, BANDWIDTH_CHARGE_MODE_TRAFFIC}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateSharedBandwidthEips(input SharedBandwidthEipsInput, isAdd bool) (output SharedBandwidthEipsOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkSharedBandwidthEipsParam(input, isAdd); err != nil {
+		return
+	}
+	publicIpIds, err := GetArrayFromString(input.PublicIpIds, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	bandwidth, exist, err := getSharedBandwidthById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("shared bandwidth(%v) is not exist", input.Id)
+		return
+	}
+
+	// only move the public ips which are not in the expected bandwidth
+	existed := map[string]bool{}
+	for _, publicIpId := range getSharedBandwidthPublicIpIds(bandwidth) {
+		existed[publicIpId] = true
+	}
+	publicIpInfos := []bandwidthsv2.PublicIpInfoID{}
+	for _, publicIpId := range publicIpIds {
+		if existed[publicIpId] != isAdd {
+			publicIpInfos = append(publicIpInfos, bandwidthsv2.PublicIpInfoID{PublicIPID: publicIpId})
+		}
+	}
+	defer func() {
+		if bandwidth, _, getErr := getSharedBandwidthById(sc, input.Id); getErr == nil && bandwidth != nil {
+			output.PublicIpIds = strings.Join(getSharedBandwidthPublicIpIds(bandwidth), ",")
+		}
+	}()
+	if len(publicIpInfos) == 0 {
+		logrus.Infof("shared bandwidth(%v) public ips are not changed", input.Id)
+		return
+	}
+
+	scV2, err := createVpcServiceClientV2(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	if isAdd {
+		_, err = bandwidthsv2.Insert(scV2, input.Id, bandwidthsv2.BandWidthInsertOpts{
+			PublicipInfo: publicIpInfos,
+		}).Extract()
+	} else {
+		chargeMode := input.ChargeMode
+		if chargeMode == "" {
+			chargeMode = BANDWIDTH_CHARGE_MODE_BANDWIDTH
+		}
+		size, _ := strconv.Atoi(input.BandWidth)
+		err = bandwidthsv2.Remove(scV2, input.Id, bandwidthsv2.BandWidthRemoveOpts{
+			ChargeMode:   chargeMode,
+			Size:         &size,
+			PublicipInfo: publicIpInfos,
+		}).ExtractErr()
+	}
+	if err != nil {
+		logrus.Errorf("update shared bandwidth(%v) public ips(%v) meet err=%v", input.Id, publicIpInfos, err)
+	}
+	return
+}
+
+func (action *SharedBandwidthAddEipsAction) Do(inputs interface{}) (interface{}, error) {
+	bandwidths, _ := inputs.(SharedBandwidthEipsInputs)
+	outputs := SharedBandwidthEipsOutputs{}
+	var finalErr error
+
+	for _, input := range bandwidths.Inputs {
+		output, err := updateSharedBandwidthEips(input, true)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+func (action *SharedBandwidthRemoveEipsAction) Do(inputs interface{}) (interface{}, error) {
+	bandwidths, _ := inputs.(SharedBandwidthEipsInputs)
+	outputs := SharedBandwidthEipsOutputs{}
+	var finalErr error
+
+	for _, input := range bandwidths.Inputs {
+		output, err := updateSharedBandwidthEips(input, false)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
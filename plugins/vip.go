@@ -0,0 +1,535 @@
+package plugins
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/vpc/v1/ports"
+	"github.com/gophercloud/gophercloud/openstack/vpc/v1/privateips"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	VIP_DEVICE_OWNER = "neutron:VIP_PORT"
+)
+
+var vipActions = make(map[string]Action)
+
+func init() {
+	vipActions["create"] = new(VipCreateAction)
+	vipActions["delete"] = new(VipDeleteAction)
+	vipActions["bind"] = new(VipBindAction)
+	vipActions["unbind"] = new(VipUnbindAction)
+}
+
+type VipPlugin struct {
+}
+
+func (plugin *VipPlugin) GetActionByName(actionName string) (Action, error) {
+	action, found := vipActions[actionName]
+	if !found {
+		logrus.Errorf("vip plugin,action = %s not found", actionName)
+		return nil, fmt.Errorf("vip plugin,action = %s not found", actionName)
+	}
+	return action, nil
+}
+
+// the sdk private ip has no device owner, which makes the ip a virtual ip
+type vipCreateOpts struct {
+	SubnetId  string
+	IpAddress string
+}
+
+func (opts vipCreateOpts) ToPrivateipsCreateMap() (map[string]interface{}, error) {
+	privateIp := map[string]interface{}{
+		"subnet_id":    opts.SubnetId,
+		"device_owner": VIP_DEVICE_OWNER,
+	}
+	if opts.IpAddress != "" {
+		privateIp["ip_address"] = opts.IpAddress
+	}
+	return map[string]interface{}{
+		"privateips": []interface{}{privateIp},
+	}, nil
+}
+
+// the id of the vip is also the id of its port
+func getVipById(sc *gophercloud.ServiceClient, id string) (*privateips.PrivateIp, bool, error) {
+	vip, err := privateips.Get(sc, id).Extract()
+	if err != nil {
+		if ue, ok := err.(*gophercloud.UnifiedError); ok {
+			if strings.Contains(ue.Message(), "could not be found") || strings.Contains(ue.Message(), "not exist") {
+				return nil, false, nil
+			}
+		}
+		logrus.Errorf("get vip(%v) meet err=%v", id, err)
+		return nil, false, err
+	}
+	return vip, true, nil
+}
+
+// find the nic of the vm in the subnet of the vip
+func getVmPortInSubnet(sc *gophercloud.ServiceClient, vmId string, subnetId string) (*ports.Port, error) {
+	allPages, err := ports.List(sc, ports.ListOpts{
+		DeviceId:  vmId,
+		NetworkId: subnetId,
+	}).AllPages()
+	if err != nil {
+		logrus.Errorf("list vm(%v) ports meet err=%v", vmId, err)
+		return nil, err
+	}
+	vmPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmPorts) == 0 {
+		return nil, fmt.Errorf("vm(%v) has no nic in subnet(%v)", vmId, subnetId)
+	}
+	return &vmPorts[0], nil
+}
+
+// the sdk update omits the empty list, so put the body directly
+func updatePortAllowedAddressPairs(sc *gophercloud.ServiceClient, portId string, pairs []ports.AllowedAddressPair) error {
+	body := map[string]interface{}{
+		"port": map[string]interface{}{
+			"allowed_address_pairs": pairs,
+		},
+	}
+	_, err := sc.Put(ports.UpdateURL(sc, portId), body, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		logrus.Errorf("update port(%v) allowed address pairs meet err=%v", portId, err)
+	}
+	return err
+}
+
+func isVipInPort(port *ports.Port, vipAddress string) bool {
+	for _, pair := range port.AllowedAddressPairs {
+		if pair.IpAddress == vipAddress {
+			return true
+		}
+	}
+	return false
+}
+
+func bindVipToPort(sc *gophercloud.ServiceClient, port *ports.Port, vipAddress string) error {
+	if isVipInPort(port, vipAddress) {
+		return nil
+	}
+	pairs := append(port.AllowedAddressPairs, ports.AllowedAddressPair{IpAddress: vipAddress})
+	return updatePortAllowedAddressPairs(sc, port.ID, pairs)
+}
+
+func unbindVipFromPort(sc *gophercloud.ServiceClient, port *ports.Port, vipAddress string) error {
+	pairs := []ports.AllowedAddressPair{}
+	for _, pair := range port.AllowedAddressPairs {
+		if pair.IpAddress != vipAddress {
+			pairs = append(pairs, pair)
+		}
+	}
+	if len(pairs) == len(port.AllowedAddressPairs) {
+		return nil
+	}
+	return updatePortAllowedAddressPairs(sc, port.ID, pairs)
+}
+
+func bindVipToVms(sc *gophercloud.ServiceClient, vip *privateips.PrivateIp, vmIds []string, isBind bool) error {
+	for _, vmId := range vmIds {
+		port, err := getVmPortInSubnet(sc, vmId, vip.SubnetId)
+		if err != nil {
+			return err
+		}
+		if isBind {
+			err = bindVipToPort(sc, port, vip.IpAddress)
+		} else {
+			err = unbindVipFromPort(sc, port, vip.IpAddress)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//--------------create vip------------------//
+type VipCreateInputs struct {
+	Inputs []VipCreateInput `json:"inputs,omitempty"`
+}
+
+type VipCreateInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid       string `json:"guid,omitempty"`
+	Id         string `json:"id,omitempty"`
+	SubnetId   string `json:"subnet_id,omitempty"`
+	IpAddress  string `json:"ip_address,omitempty"`
+	VmIds      string `json:"vm_ids,omitempty"`
+	PublicIpId string `json:"public_ip_id,omitempty"`
+}
+
+type VipCreateOutputs struct {
+	Outputs []VipCreateOutput `json:"outputs,omitempty"`
+}
+
+type VipCreateOutput struct {
+	CallBackParameter
+	Result
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	IpAddress string `json:"ip_address,omitempty"`
+}
+
+type VipCreateAction struct {
+}
+
+func (action *VipCreateAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs VipCreateInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func checkVipCreateParam(input VipCreateInput) error {
+	if err := isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return err
+	}
+	if input.SubnetId == "" {
+		return fmt.Errorf("subnetId is empty")
+	}
+	if input.IpAddress != "" && net.ParseIP(input.IpAddress) == nil {
+		return fmt.Errorf("ipAddress(%v) is invalid", input.IpAddress)
+	}
+	return nil
+}
+
+func createVip(input VipCreateInput) (output VipCreateOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = checkVipCreateParam(input); err != nil {
+		return
+	}
+	vmIds, err := GetArrayFromString(input.VmIds, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+
+	var vip *privateips.PrivateIp
+	if input.Id != "" {
+		exist := false
+		if vip, exist, err = getVipById(sc, input.Id); err != nil {
+			return
+		}
+		if !exist {
+			vip = nil
+		}
+	}
+	if vip == nil {
+		vips, createErr := privateips.Create(sc, vipCreateOpts{
+			SubnetId:  input.SubnetId,
+			IpAddress: input.IpAddress,
+		}).Extract()
+		if createErr != nil {
+			err = createErr
+			logrus.Errorf("create vip meet err=%v", err)
+			return
+		}
+		if len(*vips) == 0 {
+			err = fmt.Errorf("create vip in subnet(%v) returns nothing", input.SubnetId)
+			return
+		}
+		vip = &(*vips)[0]
+	}
+	output.Id = vip.ID
+	output.IpAddress = vip.IpAddress
+
+	// binding an existing vip again is harmless, so the idempotent create also binds
+	if err = bindVipToVms(sc, vip, vmIds, true); err != nil {
+		return
+	}
+	if input.PublicIpId == "" {
+		return
+	}
+	publicIp, err := getPublicIpInfo(input.CloudProviderParam, input.PublicIpId)
+	if err != nil || publicIp.PortId == vip.ID {
+		return
+	}
+	err = updatePublicIpPortId(input.CloudProviderParam, input.PublicIpId, vip.ID)
+	return
+}
+
+func (action *VipCreateAction) Do(inputs interface{}) (interface{}, error) {
+	vips, _ := inputs.(VipCreateInputs)
+	outputs := VipCreateOutputs{}
+	var finalErr error
+
+	for _, input := range vips.Inputs {
+		output, err := createVip(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all vips = %v are created", vips)
+	return &outputs, finalErr
+}
+
+//--------------delete vip------------------//
+type VipDeleteInputs struct {
+	Inputs []VipDeleteInput `json:"inputs,omitempty"`
+}
+
+type VipDeleteInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type VipDeleteOutputs struct {
+	Outputs []VipDeleteOutput `json:"outputs,omitempty"`
+}
+
+type VipDeleteOutput struct {
+	CallBackParameter
+	Result
+	Guid string `json:"guid,omitempty"`
+	Id   string `json:"id,omitempty"`
+}
+
+type VipDeleteAction struct {
+}
+
+func (action *VipDeleteAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs VipDeleteInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func deleteVip(input VipDeleteInput) (output VipDeleteOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	vip, exist, err := getVipById(sc, input.Id)
+	if err != nil || !exist {
+		return
+	}
+
+	// the vip should be unbound from the eip and all the nics before deleting
+	publicIp, err := getPublicIpByPortId(input.CloudProviderParam, vip.ID)
+	if err != nil && !isPublicIpNotFoundByPortErr(err) {
+		return
+	}
+	if err == nil {
+		if err = unbindPublicIpPort(input.CloudProviderParam, publicIp.ID); err != nil {
+			return
+		}
+	}
+	allPages, err := ports.List(sc, ports.ListOpts{NetworkId: vip.SubnetId}).AllPages()
+	if err != nil {
+		logrus.Errorf("list subnet(%v) ports meet err=%v", vip.SubnetId, err)
+		return
+	}
+	subnetPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return
+	}
+	for i := range subnetPorts {
+		if !isVipInPort(&subnetPorts[i], vip.IpAddress) {
+			continue
+		}
+		if err = unbindVipFromPort(sc, &subnetPorts[i], vip.IpAddress); err != nil {
+			return
+		}
+	}
+
+	if err = privateips.Delete(sc, vip.ID).ExtractErr(); err != nil {
+		logrus.Errorf("delete vip(%v) meet err=%v", vip.ID, err)
+	}
+	return
+}
+
+func (action *VipDeleteAction) Do(inputs interface{}) (interface{}, error) {
+	vips, _ := inputs.(VipDeleteInputs)
+	outputs := VipDeleteOutputs{}
+	var finalErr error
+
+	for _, input := range vips.Inputs {
+		output, err := deleteVip(input)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	logrus.Infof("all vips = %v are deleted", vips)
+	return &outputs, finalErr
+}
+
+//--------------bind and unbind vip------------------//
+type VipBindInputs struct {
+	Inputs []VipBindInput `json:"inputs,omitempty"`
+}
+
+type VipBindInput struct {
+	CallBackParameter
+	CloudProviderParam
+	Guid  string `json:"guid,omitempty"`
+	Id    string `json:"id,omitempty"`
+	VmIds string `json:"vm_ids,omitempty"`
+}
+
+type VipBindOutputs struct {
+	Outputs []VipBindOutput `json:"outputs,omitempty"`
+}
+
+type VipBindOutput struct {
+	CallBackParameter
+	Result
+	Guid      string `json:"guid,omitempty"`
+	Id        string `json:"id,omitempty"`
+	IpAddress string `json:"ip_address,omitempty"`
+}
+
+type VipBindAction struct {
+}
+
+func (action *VipBindAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs VipBindInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+type VipUnbindAction struct {
+}
+
+func (action *VipUnbindAction) ReadParam(param interface{}) (interface{}, error) {
+	var inputs VipBindInputs
+	err := UnmarshalJson(param, &inputs)
+	if err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+func bindOrUnbindVip(input VipBindInput, isBind bool) (output VipBindOutput, err error) {
+	defer func() {
+		output.Guid = input.Guid
+		output.Id = input.Id
+		output.CallBackParameter.Parameter = input.CallBackParameter.Parameter
+		if err == nil {
+			output.Result.Code = RESULT_CODE_SUCCESS
+		} else {
+			output.Result.Code = RESULT_CODE_ERROR
+			output.Result.Message = err.Error()
+		}
+	}()
+
+	if err = isCloudProviderParamValid(input.CloudProviderParam); err != nil {
+		return
+	}
+	if input.Id == "" {
+		err = fmt.Errorf("id is empty")
+		return
+	}
+	vmIds, err := GetArrayFromString(input.VmIds, ARRAY_SIZE_REAL, 0)
+	if err != nil {
+		return
+	}
+	if len(vmIds) == 0 {
+		err = fmt.Errorf("vmIds is empty")
+		return
+	}
+
+	sc, err := CreateVpcServiceClientV1(input.CloudProviderParam)
+	if err != nil {
+		return
+	}
+	vip, exist, err := getVipById(sc, input.Id)
+	if err != nil {
+		return
+	}
+	if !exist {
+		err = fmt.Errorf("vip(%v) is not exist", input.Id)
+		return
+	}
+	output.IpAddress = vip.IpAddress
+	err = bindVipToVms(sc, vip, vmIds, isBind)
+	return
+}
+
+func (action *VipBindAction) Do(inputs interface{}) (interface{}, error) {
+	vips, _ := inputs.(VipBindInputs)
+	outputs := VipBindOutputs{}
+	var finalErr error
+
+	for _, input := range vips.Inputs {
+		output, err := bindOrUnbindVip(input, true)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}
+
+func (action *VipUnbindAction) Do(inputs interface{}) (interface{}, error) {
+	vips, _ := inputs.(VipBindInputs)
+	outputs := VipBindOutputs{}
+	var finalErr error
+
+	for _, input := range vips.Inputs {
+		output, err := bindOrUnbindVip(input, false)
+		if err != nil {
+			finalErr = err
+		}
+		outputs.Outputs = append(outputs.Outputs, output)
+	}
+
+	return &outputs, finalErr
+}